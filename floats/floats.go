@@ -0,0 +1,149 @@
+// Copyright 2012 Nuno Antunes. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package floats is a float64 port of the core itertools functions, for
+// signal-processing and other pipelines that are entirely float64 and
+// would otherwise need a hand copy-pasted s/int/float64/ of the int-only
+// itertools package. Until generics-based v2 functions cover the whole
+// API, this package fills the gap for the most commonly needed ones.
+package floats
+
+// CountF returns a slice with step-spaced values from the range
+// beginning with start and ending before stop.
+//
+//  CountF(0, 1, 0.25) -> [0 0.25 0.5 0.75]
+func CountF(start, stop, step float64) []float64 {
+	if step*(stop-start) <= 0 {
+		return nil
+	}
+
+	results := []float64{}
+
+	for i := start; (step > 0 && i < stop) ||
+		(step < 0 && i > stop); i += step {
+		results = append(results, i)
+	}
+
+	return results
+}
+
+// ChainF returns a slice consisting of the elements within iterables.
+//  ChainF([]float64{1, 2}, []float64{3, 4}) -> [1 2 3 4]
+func ChainF(iterables ...[]float64) []float64 {
+	results := []float64{}
+
+	for _, v := range iterables {
+		results = append(results, v...)
+	}
+
+	return results
+}
+
+// CompressF returns a slice of the elements of data for which the
+// corresponding element of selectors is true.
+//  CompressF([]float64{1, 2, 3, 4}, []bool{true, false, true, false}) -> [1 3]
+func CompressF(data []float64, selectors []bool) []float64 {
+	n := len(data)
+	if len(selectors) < n {
+		n = len(selectors)
+	}
+
+	results := []float64{}
+
+	for i := 0; i < n; i++ {
+		if selectors[i] {
+			results = append(results, data[i])
+		}
+	}
+
+	return results
+}
+
+// TakeWhileF returns the leading elements of iterable for which
+// predicate is true, stopping at the first element where it is false.
+//
+// A nil predicate returns an empty slice.
+func TakeWhileF(predicate func(float64) bool, iterable []float64) []float64 {
+	results := []float64{}
+
+	if predicate != nil {
+		for _, v := range iterable {
+			if predicate(v) {
+				results = append(results, v)
+			} else {
+				break
+			}
+		}
+	}
+
+	return results
+}
+
+// IZipF aggregates elements from each of the iterables, truncating to
+// the shortest input.
+//  IZipF([]float64{1, 2}, []float64{3, 4}) -> [[1 3] [2 4]]
+func IZipF(iterables ...[]float64) [][]float64 {
+	if len(iterables) == 0 {
+		return nil
+	}
+
+	n := len(iterables[0])
+	for _, v := range iterables[1:] {
+		if len(v) < n {
+			n = len(v)
+		}
+	}
+
+	results := make([][]float64, n)
+	for i := range results {
+		tuple := make([]float64, len(iterables))
+		for j, v := range iterables {
+			tuple[j] = v[i]
+		}
+		results[i] = tuple
+	}
+
+	return results
+}
+
+// ProductF computes the Cartesian product of the input pools.
+//
+// Any empty pool returns nil.
+//  ProductF([]float64{1, 2}, []float64{3, 4}) -> [[1 3] [1 4] [2 3] [2 4]]
+func ProductF(pools ...[]float64) [][]float64 {
+	npools := len(pools)
+	indices := make([]int, npools)
+
+	result := make([]float64, npools)
+	for i := range result {
+		if len(pools[i]) == 0 {
+			return nil
+		}
+		result[i] = pools[i][0]
+	}
+
+	results := [][]float64{result}
+
+	for {
+		i := npools - 1
+		for ; i >= 0; i-- {
+			indices[i]++
+			if indices[i] < len(pools[i]) {
+				break
+			}
+			indices[i] = 0
+		}
+
+		if i < 0 {
+			return results
+		}
+
+		tuple := make([]float64, npools)
+		for j, pool := range pools {
+			tuple[j] = pool[indices[j]]
+		}
+
+		results = append(results, tuple)
+	}
+}
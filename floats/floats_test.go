@@ -0,0 +1,102 @@
+// Copyright 2012 Nuno Antunes. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package floats
+
+import "testing"
+
+func TestCountF(t *testing.T) {
+	// general case
+	if v := CountF(0, 1, 0.25); !sliceMatch(v, []float64{0, 0.25, 0.5, 0.75}) {
+		t.Errorf("CountF(0, 1, 0.25) should return [0 0.25 0.5 0.75], got %v", v)
+	}
+
+	// step*(stop-start) <= 0
+	if v := CountF(1, 10, 0); v != nil {
+		t.Errorf("CountF(1, 10, 0) should return nil, got %v", v)
+	}
+	if v := CountF(1, 10, -1); v != nil {
+		t.Errorf("CountF(1, 10, -1) should return nil, got %v", v)
+	}
+}
+
+func TestChainF(t *testing.T) {
+	// general case
+	if v := ChainF([]float64{1, 2}, []float64{3, 4}); !sliceMatch(v, []float64{1, 2, 3, 4}) {
+		t.Errorf("ChainF([1 2], [3 4]) should return [1 2 3 4], got %v", v)
+	}
+}
+
+func TestCompressF(t *testing.T) {
+	// general case
+	if v := CompressF([]float64{1, 2, 3, 4}, []bool{true, false, true, false}); !sliceMatch(v, []float64{1, 3}) {
+		t.Errorf("CompressF([1 2 3 4], [true false true false]) should return [1 3], got %v", v)
+	}
+}
+
+func TestTakeWhileF(t *testing.T) {
+	isPositive := func(x float64) bool { return x > 0 }
+
+	// general case
+	if v := TakeWhileF(isPositive, []float64{1, 2, -1, 3}); !sliceMatch(v, []float64{1, 2}) {
+		t.Errorf("TakeWhileF(isPositive, [1 2 -1 3]) should return [1 2], got %v", v)
+	}
+
+	// nil predicate
+	if v := TakeWhileF(nil, []float64{1, 2}); !sliceMatch(v, []float64{}) {
+		t.Errorf("TakeWhileF(nil, [1 2]) should return [], got %v", v)
+	}
+}
+
+func TestIZipF(t *testing.T) {
+	// general case
+	if v := IZipF([]float64{1, 2}, []float64{3, 4}); !slice2dMatch(v, [][]float64{{1, 3}, {2, 4}}) {
+		t.Errorf("IZipF([1 2], [3 4]) should return [[1 3] [2 4]], got %v", v)
+	}
+
+	// no iterables
+	if v := IZipF(); v != nil {
+		t.Errorf("IZipF() should return nil, got %v", v)
+	}
+}
+
+func TestProductF(t *testing.T) {
+	// general case
+	if v := ProductF([]float64{1, 2}, []float64{3, 4}); !slice2dMatch(v, [][]float64{{1, 3}, {1, 4}, {2, 3}, {2, 4}}) {
+		t.Errorf("ProductF([1 2], [3 4]) should return [[1 3] [1 4] [2 3] [2 4]], got %v", v)
+	}
+
+	// empty pool
+	if v := ProductF([]float64{1, 2}, []float64{}); v != nil {
+		t.Errorf("ProductF([1 2], []) should return nil, got %v", v)
+	}
+}
+
+func sliceMatch(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func slice2dMatch(a, b [][]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, s := range a {
+		if !sliceMatch(s, b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
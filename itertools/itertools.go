@@ -5,6 +5,11 @@
 // Package itertools is a (limited) port of Python's itertools module.
 package itertools
 
+import (
+	"fmt"
+	"sort"
+)
+
 // Count returns a slice with step-spaced values from the range
 // beginning with start and ending before stop.
 //
@@ -420,3 +425,3171 @@ func Combinations(iterable []int, r int) [][]int {
 		results = append(results, result)
 	}
 }
+
+// SetPartitions returns every way to partition iterable into non-empty
+// disjoint subsets. The number of partitions returned is the Bell
+// number of len(iterable), so this grows exponentially and should only
+// be used for small inputs.
+//
+//  SetPartitions([]int{1, 2, 3}) -> [[[3 2 1]] [[3 2] [1]] [[3 1] [2]] [[3] [2 1]] [[3] [2] [1]]]
+func SetPartitions(iterable []int) [][][]int {
+	if len(iterable) == 0 {
+		return [][][]int{{}}
+	}
+
+	first := iterable[0]
+	rest := SetPartitions(iterable[1:])
+
+	results := [][][]int{}
+
+	for _, partition := range rest {
+		for i := range partition {
+			newPartition := make([][]int, len(partition))
+			for j, subset := range partition {
+				newPartition[j] = append([]int{}, subset...)
+			}
+			newPartition[i] = append(newPartition[i], first)
+			results = append(results, newPartition)
+		}
+
+		newPartition := make([][]int, len(partition)+1)
+		copy(newPartition, partition)
+		newPartition[len(partition)] = []int{first}
+		results = append(results, newPartition)
+	}
+
+	return results
+}
+
+// EditOp identifies the kind of change an Edit represents.
+type EditOp int
+
+// The supported EditOp values.
+const (
+	Keep EditOp = iota
+	Insert
+	Delete
+)
+
+// Edit describes a single step in transforming one slice into another.
+// Keep and Delete apply to the element at the current position in the
+// source slice; Insert introduces Value without consuming from the
+// source.
+type Edit struct {
+	Op    EditOp
+	Value int
+}
+
+// Diff returns the sequence of Edits that transforms a into b, computed
+// from their longest common subsequence. Elements of the LCS become
+// Keep edits and the remainder become Insert/Delete edits.
+//
+//  Apply(a, Diff(a, b)) -> b
+func Diff(a, b []int) []Edit {
+	n, m := len(a), len(b)
+
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, m+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else if table[i+1][j] >= table[i][j+1] {
+				table[i][j] = table[i+1][j]
+			} else {
+				table[i][j] = table[i][j+1]
+			}
+		}
+	}
+
+	edits := []Edit{}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, Edit{Keep, a[i]})
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			edits = append(edits, Edit{Delete, a[i]})
+			i++
+		default:
+			edits = append(edits, Edit{Insert, b[j]})
+			j++
+		}
+	}
+
+	for ; i < n; i++ {
+		edits = append(edits, Edit{Delete, a[i]})
+	}
+
+	for ; j < m; j++ {
+		edits = append(edits, Edit{Insert, b[j]})
+	}
+
+	return edits
+}
+
+// Apply replays edits against a, reconstructing the slice the edits
+// were diffed against.
+func Apply(a []int, edits []Edit) []int {
+	results := []int{}
+
+	i := 0
+	for _, e := range edits {
+		switch e.Op {
+		case Keep:
+			results = append(results, a[i])
+			i++
+		case Delete:
+			i++
+		case Insert:
+			results = append(results, e.Value)
+		}
+	}
+
+	return results
+}
+
+// IZipTolerant aggregates elements from each of the iterables like
+// IZip, truncating to the shortest input, but returns an error if the
+// difference between the longest and shortest input lengths exceeds
+// maxDiff. This catches gross misalignment while tolerating small,
+// expected differences in length.
+func IZipTolerant(maxDiff int, iterables ...[]int) ([][]int, error) {
+	if len(iterables) == 0 {
+		return nil, nil
+	}
+
+	min, max := len(iterables[0]), len(iterables[0])
+	for _, v := range iterables[1:] {
+		if len(v) < min {
+			min = len(v)
+		}
+		if len(v) > max {
+			max = len(v)
+		}
+	}
+
+	if max-min > maxDiff {
+		return nil, fmt.Errorf("itertools: IZipTolerant length difference %d exceeds maxDiff %d", max-min, maxDiff)
+	}
+
+	return IZip(iterables...), nil
+}
+
+// Derangements returns every permutation of iterable in which no
+// element remains at its original position, built atop Permutations.
+// The number of derangements returned is the subfactorial !n.
+//
+// An empty input returns a single empty derangement; a single-element
+// input returns nil, since no derangement of one element exists.
+//  Derangements([]int{1, 2, 3}) -> [[2 3 1] [3 1 2]]
+func Derangements(iterable []int) [][]int {
+	n := len(iterable)
+	if n == 0 {
+		return [][]int{{}}
+	}
+
+	results := [][]int{}
+
+	for _, p := range Permutations(iterable, n) {
+		deranged := true
+		for i, v := range p {
+			if v == iterable[i] {
+				deranged = false
+				break
+			}
+		}
+
+		if deranged {
+			results = append(results, p)
+		}
+	}
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	return results
+}
+
+// GrayCode returns the reflected binary Gray code sequence of the
+// given bit width: 2^bits integers in which consecutive values
+// (including the wrap from last to first) differ by exactly one bit.
+//
+// bits < 0 returns nil; bits == 0 returns []int{0}.
+//  GrayCode(2) -> [0 1 3 2]
+func GrayCode(bits int) []int {
+	if bits < 0 {
+		return nil
+	}
+
+	n := 1 << uint(bits)
+	results := make([]int, n)
+
+	for i := 0; i < n; i++ {
+		results[i] = i ^ (i >> 1)
+	}
+
+	return results
+}
+
+// ChunkedStrict splits iterable into groups of size elements, erroring
+// if len(iterable) is not an exact multiple of size. Unlike a padding
+// chunker, every returned group is exactly size long.
+//
+//  ChunkedStrict([]int{1, 2, 3, 4}, 2) -> [[1 2] [3 4]], nil
+//  ChunkedStrict([]int{1, 2, 3}, 2) -> nil, error
+func ChunkedStrict(iterable []int, size int) ([][]int, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("itertools: ChunkedStrict size must be positive, got %d", size)
+	}
+
+	if len(iterable)%size != 0 {
+		return nil, fmt.Errorf("itertools: ChunkedStrict iterable of length %d is not a multiple of size %d", len(iterable), size)
+	}
+
+	results := [][]int{}
+
+	for i := 0; i < len(iterable); i += size {
+		chunk := make([]int, size)
+		copy(chunk, iterable[i:i+size])
+		results = append(results, chunk)
+	}
+
+	return results, nil
+}
+
+// SlidingArgmax returns, for each contiguous window of size elements,
+// the index into iterable of the maximum element in that window. Ties
+// within a window resolve to the leftmost index.
+//
+// size <= 0 returns nil; a size larger than iterable returns an empty
+// slice.
+//  SlidingArgmax([]int{1, 3, 2, 5, 4}, 3) -> [1 3 3]
+func SlidingArgmax(iterable []int, size int) []int {
+	if size <= 0 {
+		return nil
+	}
+
+	n := len(iterable)
+	if size > n {
+		return []int{}
+	}
+
+	results := []int{}
+
+	for i := 0; i+size <= n; i++ {
+		best := i
+		for j := i + 1; j < i+size; j++ {
+			if iterable[j] > iterable[best] {
+				best = j
+			}
+		}
+
+		results = append(results, best)
+	}
+
+	return results
+}
+
+// IsSubsequence returns whether sub appears within iterable as a (not
+// necessarily contiguous) subsequence, preserving order. An empty sub
+// is always a subsequence.
+//
+//  IsSubsequence([]int{2, 4}, []int{1, 2, 3, 4}) -> true
+//  IsSubsequence([]int{4, 2}, []int{1, 2, 3, 4}) -> false
+func IsSubsequence(sub, iterable []int) bool {
+	i := 0
+
+	for _, v := range iterable {
+		if i == len(sub) {
+			break
+		}
+
+		if v == sub[i] {
+			i++
+		}
+	}
+
+	return i == len(sub)
+}
+
+// IsSubslice returns whether sub appears within iterable as a
+// contiguous run of elements, in order.
+//
+//  IsSubslice([]int{2, 3}, []int{1, 2, 3, 4}) -> true
+//  IsSubslice([]int{2, 4}, []int{1, 2, 3, 4}) -> false
+func IsSubslice(sub, iterable []int) bool {
+	n, m := len(sub), len(iterable)
+
+	if n == 0 {
+		return true
+	}
+
+	for i := 0; i+n <= m; i++ {
+		if sliceEqual(sub, iterable[i:i+n]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sliceEqual reports whether a and b contain the same elements in the
+// same order.
+func sliceEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LCS returns the longest common subsequence of a and b, computed with
+// the same dynamic-programming recurrence as Diff. When several
+// subsequences of equal length exist, ties are broken the way Diff
+// breaks them: elements of a are skipped before elements of b.
+//
+//  LCS([]int{1, 2, 3, 4}, []int{2, 4, 3}) -> [2 4]
+func LCS(a, b []int) []int {
+	n, m := len(a), len(b)
+
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, m+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else if table[i+1][j] >= table[i][j+1] {
+				table[i][j] = table[i+1][j]
+			} else {
+				table[i][j] = table[i][j+1]
+			}
+		}
+	}
+
+	results := []int{}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			results = append(results, a[i])
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return results
+}
+
+// IntPartitions returns every way to write n as a sum of positive
+// integers, with each partition sorted in non-increasing order. The
+// number of partitions returned is the partition function p(n).
+//
+// n == 0 returns a single empty partition; n < 0 returns nil.
+//  IntPartitions(4) -> [[4] [3 1] [2 2] [2 1 1] [1 1 1 1]]
+func IntPartitions(n int) [][]int {
+	if n < 0 {
+		return nil
+	}
+
+	return intPartitionsMax(n, n)
+}
+
+// intPartitionsMax returns every partition of n whose largest part is
+// at most max.
+func intPartitionsMax(n, max int) [][]int {
+	if n == 0 {
+		return [][]int{{}}
+	}
+
+	results := [][]int{}
+
+	top := n
+	if max < top {
+		top = max
+	}
+
+	for i := top; i >= 1; i-- {
+		for _, rest := range intPartitionsMax(n-i, i) {
+			part := append([]int{i}, rest...)
+			results = append(results, part)
+		}
+	}
+
+	return results
+}
+
+// SlidingDistinctCount returns, for each contiguous window of size
+// elements, the number of distinct values it contains. The count is
+// tracked with an incremental frequency map updated as the window
+// slides, rather than rebuilding a set for every window.
+//
+// size <= 0 returns nil; a size larger than iterable returns an empty
+// slice.
+//  SlidingDistinctCount([]int{1, 1, 2, 3, 3}, 3) -> [2 3 2]
+func SlidingDistinctCount(iterable []int, size int) []int {
+	if size <= 0 {
+		return nil
+	}
+
+	n := len(iterable)
+	if size > n {
+		return []int{}
+	}
+
+	freq := map[int]int{}
+	for i := 0; i < size; i++ {
+		freq[iterable[i]]++
+	}
+
+	results := []int{len(freq)}
+
+	for i := size; i < n; i++ {
+		freq[iterable[i]]++
+
+		out := iterable[i-size]
+		freq[out]--
+		if freq[out] == 0 {
+			delete(freq, out)
+		}
+
+		results = append(results, len(freq))
+	}
+
+	return results
+}
+
+// RoundRobinWeighted interleaves iterables proportionally to their
+// lengths, so that shorter inputs are spread out across the output
+// instead of being exhausted in the first few rounds the way plain
+// round-robin interleaving would bunch them.
+//
+// At each step it picks the iterable whose next element has the
+// smallest "position fraction" (consumed+1)/len among those not yet
+// exhausted (ties go to the earlier iterable), comparing fractions by cross-multiplication to stay in
+// integer arithmetic (the same spacing idea behind Bresenham's line
+// algorithm).
+func RoundRobinWeighted(iterables ...[]int) []int {
+	n := len(iterables)
+	lens := make([]int, n)
+	consumed := make([]int, n)
+
+	total := 0
+	for i, v := range iterables {
+		lens[i] = len(v)
+		total += lens[i]
+	}
+
+	results := make([]int, 0, total)
+
+	for len(results) < total {
+		best := -1
+		for i := 0; i < n; i++ {
+			if consumed[i] >= lens[i] {
+				continue
+			}
+			if best == -1 {
+				best = i
+				continue
+			}
+
+			lhs := (consumed[i] + 1) * lens[best]
+			rhs := (consumed[best] + 1) * lens[i]
+			if lhs < rhs {
+				best = i
+			}
+		}
+
+		results = append(results, iterables[best][consumed[best]])
+		consumed[best]++
+	}
+
+	return results
+}
+
+// TakeWhileIndexed returns elements from the iterable as long as the
+// predicate, which also receives the element's index, is true. A nil
+// predicate behaves like TakeWhile with a nil predicate.
+//
+//  TakeWhileIndexed(func(i, x int) bool { return x > i }, []int{5, 4, 1, 9}) -> [5 4]
+func TakeWhileIndexed(predicate func(i, x int) bool, iterable []int) []int {
+	results := []int{}
+
+	if predicate != nil {
+		for i, v := range iterable {
+			if predicate(i, v) {
+				results = append(results, v)
+			} else {
+				break
+			}
+		}
+	}
+
+	return results
+}
+
+// DropWhileIndexed drops elements from the iterable, as long as the
+// predicate (which also receives the element's index) is true;
+// afterwards, returns every element. A nil predicate behaves like
+// DropWhile with a nil predicate.
+func DropWhileIndexed(predicate func(i, x int) bool, iterable []int) []int {
+	results := []int{}
+
+	if predicate != nil {
+		drop := true
+		for i, v := range iterable {
+			if drop && predicate(i, v) {
+				continue
+			} else {
+				drop = false
+			}
+			results = append(results, v)
+		}
+	}
+
+	return results
+}
+
+// CompressFunc includes data[i] in the result whenever keep(i, data[i])
+// is true, generalizing Compress to a computed condition instead of a
+// precomputed selector slice. A nil keep returns a copy of data.
+//
+//  CompressFunc([]int{10, 20, 30}, func(i, x int) bool { return i%2 == 0 }) -> [10 30]
+func CompressFunc(data []int, keep func(i, x int) bool) []int {
+	results := []int{}
+
+	if keep == nil {
+		results = append(results, data...)
+		return results
+	}
+
+	for i, v := range data {
+		if keep(i, v) {
+			results = append(results, v)
+		}
+	}
+
+	return results
+}
+
+// Necklaces returns the r-length sequences over the alphabet iterable
+// that are distinct up to rotation, one canonical representative (the
+// lexicographically smallest rotation) per equivalence class.
+//
+//  Necklaces([]int{0, 1}, 3) -> [[0 0 0] [0 0 1] [0 1 1] [1 1 1]]
+func Necklaces(iterable []int, r int) [][]int {
+	if r <= 0 || len(iterable) == 0 {
+		return nil
+	}
+
+	pools := make([][]int, r)
+	for i := range pools {
+		pools[i] = iterable
+	}
+
+	seen := map[string]bool{}
+	results := [][]int{}
+
+	for _, seq := range Product(pools...) {
+		canon := necklaceCanonical(seq)
+
+		key := fmt.Sprint(canon)
+		if !seen[key] {
+			seen[key] = true
+			results = append(results, canon)
+		}
+	}
+
+	return results
+}
+
+// necklaceCanonical returns the lexicographically smallest rotation of
+// seq, the canonical representative of its necklace equivalence class.
+func necklaceCanonical(seq []int) []int {
+	best := seq
+
+	for i := 1; i < len(seq); i++ {
+		rotation := append(append([]int{}, seq[i:]...), seq[:i]...)
+		if lexLess(rotation, best) {
+			best = rotation
+		}
+	}
+
+	return append([]int{}, best...)
+}
+
+// lexLess reports whether a sorts lexicographically before b.
+func lexLess(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+
+	return len(a) < len(b)
+}
+
+// Gather returns data reindexed by indices, so the result is
+// data[indices[0]], data[indices[1]], and so on. Out-of-range indices
+// are skipped; use GatherChecked if that should be an error instead.
+//
+//  Gather([]int{10, 20, 30, 40}, []int{3, 1, 0}) -> [40 20 10]
+func Gather(data, indices []int) []int {
+	results := []int{}
+
+	for _, idx := range indices {
+		if idx < 0 || idx >= len(data) {
+			continue
+		}
+
+		results = append(results, data[idx])
+	}
+
+	return results
+}
+
+// GatherChecked is like Gather but returns an error instead of
+// skipping an out-of-range index.
+func GatherChecked(data, indices []int) ([]int, error) {
+	results := make([]int, len(indices))
+
+	for i, idx := range indices {
+		if idx < 0 || idx >= len(data) {
+			return nil, fmt.Errorf("itertools: GatherChecked index %d out of range for data of length %d", idx, len(data))
+		}
+
+		results[i] = data[idx]
+	}
+
+	return results, nil
+}
+
+// Scatter is the inverse of Gather: it places values[k] at position
+// indices[k] in a new slice of length size, filling every other
+// position with fill. When values and indices differ in length, only
+// the shorter length is used. Duplicate indices mean the later value
+// wins; out-of-range indices are skipped, mirroring Gather.
+//
+//  Scatter([]int{10, 20}, []int{2, 0}, 4, -1) -> [20 -1 10 -1]
+func Scatter(values, indices []int, size, fill int) []int {
+	results := make([]int, size)
+	for i := range results {
+		results[i] = fill
+	}
+
+	n := len(values)
+	if len(indices) < n {
+		n = len(indices)
+	}
+
+	for k := 0; k < n; k++ {
+		idx := indices[k]
+		if idx < 0 || idx >= size {
+			continue
+		}
+
+		results[idx] = values[k]
+	}
+
+	return results
+}
+
+// SymmetricDifference returns the elements that appear in an odd
+// number of the given iterables (duplicates within a single iterable
+// count once), deduplicated and in first-seen order.
+//
+//  SymmetricDifference([]int{1, 2, 3}, []int{2, 3, 4}) -> [1 4]
+func SymmetricDifference(iterables ...[]int) []int {
+	count := map[int]int{}
+	order := []int{}
+
+	for _, it := range iterables {
+		seen := map[int]bool{}
+		for _, v := range it {
+			if seen[v] {
+				continue
+			}
+			seen[v] = true
+
+			if _, ok := count[v]; !ok {
+				order = append(order, v)
+			}
+			count[v]++
+		}
+	}
+
+	results := []int{}
+
+	for _, v := range order {
+		if count[v]%2 == 1 {
+			results = append(results, v)
+		}
+	}
+
+	return results
+}
+
+// SortBy returns a new slice with the elements of iterable sorted
+// stably, ascending by key. It does not mutate iterable. A nil key
+// sorts by natural value.
+//
+//  SortBy([]int{-3, 1, -2}, abs) -> [1 -2 -3]
+func SortBy(iterable []int, key func(int) int) []int {
+	if key == nil {
+		key = func(x int) int { return x }
+	}
+
+	results := append([]int{}, iterable...)
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return key(results[i]) < key(results[j])
+	})
+
+	return results
+}
+
+// SortByDescending is like SortBy but sorts stably in descending order
+// of key.
+func SortByDescending(iterable []int, key func(int) int) []int {
+	if key == nil {
+		key = func(x int) int { return x }
+	}
+
+	results := append([]int{}, iterable...)
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return key(results[i]) > key(results[j])
+	})
+
+	return results
+}
+
+// Runs returns the maximal contiguous runs of elements satisfying
+// predicate, discarding the non-matching gaps between them. A nil
+// predicate returns runs of elements greater than 0.
+//
+//  Runs(isPositive, []int{1, 2, -1, 3, 4, -2, 5}) -> [[1 2] [3 4] [5]]
+func Runs(predicate func(int) bool, iterable []int) [][]int {
+	if predicate == nil {
+		predicate = func(x int) bool { return x > 0 }
+	}
+
+	results := [][]int{}
+	current := []int{}
+
+	for _, v := range iterable {
+		if predicate(v) {
+			current = append(current, v)
+		} else if len(current) > 0 {
+			results = append(results, current)
+			current = []int{}
+		}
+	}
+
+	if len(current) > 0 {
+		results = append(results, current)
+	}
+
+	return results
+}
+
+// Fork applies each of transforms to an independent copy of iterable,
+// returning their results in order. Each transform receives its own
+// defensive copy, so it may mutate it in place.
+//
+//  Fork([]int{1, 2, 3}, sortAsc, reverse) -> [[1 2 3] [3 2 1]]
+func Fork(iterable []int, transforms ...func([]int) []int) [][]int {
+	results := [][]int{}
+
+	for _, transform := range transforms {
+		results = append(results, transform(append([]int{}, iterable...)))
+	}
+
+	return results
+}
+
+// EWMA returns the exponentially weighted moving average of iterable,
+// where each output is alpha*current + (1-alpha)*previous, seeded with
+// the first element.
+//
+// alpha outside [0, 1] returns nil.
+//  EWMA([]int{1, 2, 3, 4}, 0.5) -> [1 1.5 2.25 3.125]
+func EWMA(iterable []int, alpha float64) []float64 {
+	if alpha < 0 || alpha > 1 {
+		return nil
+	}
+
+	results := []float64{}
+	if len(iterable) == 0 {
+		return results
+	}
+
+	prev := float64(iterable[0])
+	results = append(results, prev)
+
+	for _, v := range iterable[1:] {
+		prev = alpha*float64(v) + (1-alpha)*prev
+		results = append(results, prev)
+	}
+
+	return results
+}
+
+// DistinctCombinations returns every value-distinct r length
+// combination of iterable, treating equal-valued elements as
+// interchangeable. Unlike Combinations, repeated input values do not
+// produce repeated results: the input is sorted and duplicate
+// branches are skipped during generation.
+//
+// r > len(iterable) returns nil; r == 0 returns a single empty
+// combination.
+//  DistinctCombinations([]int{1, 1, 2}, 2) -> [[1 1] [1 2]]
+func DistinctCombinations(iterable []int, r int) [][]int {
+	n := len(iterable)
+	if r > n {
+		return nil
+	}
+	if r == 0 {
+		return [][]int{{}}
+	}
+
+	sorted := append([]int{}, iterable...)
+	sort.Ints(sorted)
+
+	results := [][]int{}
+
+	var backtrack func(start int, current []int)
+	backtrack = func(start int, current []int) {
+		if len(current) == r {
+			results = append(results, append([]int{}, current...))
+			return
+		}
+
+		for i := start; i < n; i++ {
+			if i > start && sorted[i] == sorted[i-1] {
+				continue
+			}
+
+			backtrack(i+1, append(current, sorted[i]))
+		}
+	}
+
+	backtrack(0, []int{})
+
+	return results
+}
+
+// DistinctPermutations returns every unique ordering of iterable,
+// treating equal-valued elements as interchangeable. Unlike
+// Permutations, repeated input values do not produce repeated results.
+//
+// It generates orderings directly with the standard
+// next-permutation-over-sorted-input algorithm, rather than generating
+// and filtering the full position-based permutation set.
+//
+// An empty input returns a single empty permutation.
+//  DistinctPermutations([]int{1, 1, 2}) -> [[1 1 2] [1 2 1] [2 1 1]]
+func DistinctPermutations(iterable []int) [][]int {
+	n := len(iterable)
+	if n == 0 {
+		return [][]int{{}}
+	}
+
+	current := append([]int{}, iterable...)
+	sort.Ints(current)
+
+	results := [][]int{append([]int{}, current...)}
+
+	for {
+		k := -1
+		for i := n - 2; i >= 0; i-- {
+			if current[i] < current[i+1] {
+				k = i
+				break
+			}
+		}
+
+		if k == -1 {
+			break
+		}
+
+		l := -1
+		for i := n - 1; i > k; i-- {
+			if current[k] < current[i] {
+				l = i
+				break
+			}
+		}
+
+		current[k], current[l] = current[l], current[k]
+
+		for i, j := k+1, n-1; i < j; i, j = i+1, j-1 {
+			current[i], current[j] = current[j], current[i]
+		}
+
+		results = append(results, append([]int{}, current...))
+	}
+
+	return results
+}
+
+// SlidingReduceAligned reduces each window of size elements of
+// iterable with reduce, in one of two alignments.
+//
+// With centered false, it behaves like a valid-mode window reduce: the
+// output has len(iterable)-size+1 elements, one per fully-in-bounds
+// window.
+//
+// With centered true, the output has the same length as iterable; each
+// window is centered on its position (floor(size/2) elements before
+// it), and positions that would fall outside iterable are padded by
+// replicating the nearest boundary element.
+//
+// size <= 0 or a nil reduce returns nil. With centered false, a size
+// larger than iterable returns an empty slice.
+func SlidingReduceAligned(iterable []int, size int, reduce func([]int) int, centered bool) []int {
+	if size <= 0 || reduce == nil {
+		return nil
+	}
+
+	n := len(iterable)
+
+	if !centered {
+		if size > n {
+			return []int{}
+		}
+
+		results := []int{}
+		for i := 0; i+size <= n; i++ {
+			results = append(results, reduce(append([]int{}, iterable[i:i+size]...)))
+		}
+
+		return results
+	}
+
+	results := make([]int, n)
+	half := size / 2
+
+	for i := 0; i < n; i++ {
+		window := make([]int, size)
+		for j := 0; j < size; j++ {
+			idx := i - half + j
+			if idx < 0 {
+				idx = 0
+			}
+			if idx >= n {
+				idx = n - 1
+			}
+
+			window[j] = iterable[idx]
+		}
+
+		results[i] = reduce(window)
+	}
+
+	return results
+}
+
+// ArgSort returns the indices that would sort iterable in ascending
+// order, stably: equal elements keep their original relative order.
+// iterable itself is not mutated.
+//
+//  ArgSort([]int{30, 10, 20}) -> [1 2 0]
+func ArgSort(iterable []int) []int {
+	indices := make([]int, len(iterable))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	sort.SliceStable(indices, func(i, j int) bool {
+		return iterable[indices[i]] < iterable[indices[j]]
+	})
+
+	return indices
+}
+
+// Rank returns, for each element of iterable, the 0-based position it
+// would occupy if iterable were sorted ascending: the inverse of
+// ArgSort. Ties are broken by original order (ordinal ranking), so
+// every rank is distinct even among equal values. iterable is not
+// mutated.
+//
+//  Rank([]int{30, 10, 20}) -> [2 0 1]
+func Rank(iterable []int) []int {
+	order := ArgSort(iterable)
+
+	rank := make([]int, len(iterable))
+	for position, idx := range order {
+		rank[idx] = position
+	}
+
+	return rank
+}
+
+// MapBatched splits iterable into chunks of size elements, applies fn
+// to each chunk, and concatenates the (possibly differently-sized)
+// results. This models batch-oriented backends, such as a bulk lookup
+// API, without writing the chunking loop at every call site.
+//
+// size <= 0 returns nil; a nil fn returns an empty slice.
+//  MapBatched([]int{1, 2, 3, 4, 5}, 2, sumEach) calls sumEach([1 2]), sumEach([3 4]), sumEach([5])
+func MapBatched(iterable []int, size int, fn func([]int) []int) []int {
+	if size <= 0 {
+		return nil
+	}
+	if fn == nil {
+		return []int{}
+	}
+
+	results := []int{}
+
+	for i := 0; i < len(iterable); i += size {
+		end := i + size
+		if end > len(iterable) {
+			end = len(iterable)
+		}
+
+		results = append(results, fn(iterable[i:end])...)
+	}
+
+	return results
+}
+
+// ZipColumns is IZip's data arranged transposed from the start: instead
+// of building row tuples and transposing them, it directly returns the
+// inputs truncated to the common minimum length as columns, avoiding the
+// per-row allocations that a transpose of Unzip's input would require.
+//
+// An empty argument list returns nil.
+//  ZipColumns([]int{1, 2, 3, 4}, []int{5, 6, 7}) -> [[1 2 3] [5 6 7]]
+func ZipColumns(iterables ...[]int) [][]int {
+	if len(iterables) == 0 {
+		return nil
+	}
+
+	size := len(iterables[0])
+	for _, v := range iterables[1:] {
+		if len(v) < size {
+			size = len(v)
+		}
+	}
+
+	results := make([][]int, len(iterables))
+	for i, v := range iterables {
+		col := make([]int, size)
+		copy(col, v[:size])
+		results[i] = col
+	}
+
+	return results
+}
+
+// Interleavings returns every distinct way to merge a and b while
+// preserving the relative order within each, a combinatorial generator
+// distinct from RoundRobinWeighted, which produces only one interleaving. The
+// count of results is the binomial coefficient C(len(a)+len(b), len(a)).
+//
+// Two empty inputs return the single empty sequence as the only
+// interleaving.
+//  Interleavings([]int{1, 2}, []int{3}) -> [[1 2 3] [1 3 2] [3 1 2]]
+func Interleavings(a, b []int) [][]int {
+	if len(a) == 0 && len(b) == 0 {
+		return [][]int{{}}
+	}
+
+	results := [][]int{}
+
+	if len(a) > 0 {
+		for _, rest := range Interleavings(a[1:], b) {
+			results = append(results, append([]int{a[0]}, rest...))
+		}
+	}
+
+	if len(b) > 0 {
+		for _, rest := range Interleavings(a, b[1:]) {
+			results = append(results, append([]int{b[0]}, rest...))
+		}
+	}
+
+	return results
+}
+
+// LongestIncreasingSubsequence returns a longest strictly increasing
+// subsequence of iterable, not necessarily contiguous, using the O(n log
+// n) patience-sorting approach: tails[k] tracks the smallest tail value
+// of an increasing subsequence of length k+1, and prev reconstructs the
+// actual subsequence rather than just its length.
+//
+// Empty input returns an empty slice.
+//  LongestIncreasingSubsequence([]int{3, 1, 2, 1, 8, 5, 6}) -> [1 2 5 6]
+func LongestIncreasingSubsequence(iterable []int) []int {
+	if len(iterable) == 0 {
+		return []int{}
+	}
+
+	tails := []int{}
+	tailIndices := []int{}
+	prev := make([]int, len(iterable))
+
+	for i, v := range iterable {
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if tails[mid] < v {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+
+		if lo > 0 {
+			prev[i] = tailIndices[lo-1]
+		} else {
+			prev[i] = -1
+		}
+
+		if lo == len(tails) {
+			tails = append(tails, v)
+			tailIndices = append(tailIndices, i)
+		} else {
+			tails[lo] = v
+			tailIndices[lo] = i
+		}
+	}
+
+	result := make([]int, len(tails))
+	idx := tailIndices[len(tailIndices)-1]
+	for i := len(tails) - 1; i >= 0; i-- {
+		result[i] = iterable[idx]
+		idx = prev[idx]
+	}
+
+	return result
+}
+
+// CumulativeMax returns the running maximum of iterable up to each
+// position, a named special case of AccumulateFunc with the max operator
+// worth having for drawdown and high-water-mark calculations.
+//
+// Empty input returns an empty slice.
+//  CumulativeMax([]int{1, 3, 2, 5, 4}) -> [1 3 3 5 5]
+func CumulativeMax(iterable []int) []int {
+	result := make([]int, len(iterable))
+
+	for i, v := range iterable {
+		if i == 0 || v > result[i-1] {
+			result[i] = v
+		} else {
+			result[i] = result[i-1]
+		}
+	}
+
+	return result
+}
+
+// CumulativeMin returns the running minimum of iterable up to each
+// position, a named special case of AccumulateFunc with the min operator
+// worth having for drawdown and high-water-mark calculations.
+//
+// Empty input returns an empty slice.
+//  CumulativeMin([]int{5, 3, 4, 1, 2}) -> [5 3 3 1 1]
+func CumulativeMin(iterable []int) []int {
+	result := make([]int, len(iterable))
+
+	for i, v := range iterable {
+		if i == 0 || v < result[i-1] {
+			result[i] = v
+		} else {
+			result[i] = result[i-1]
+		}
+	}
+
+	return result
+}
+
+// SplitOn breaks iterable at every occurrence of delimiter, excluding
+// the delimiter from the output. This is a value-based split: groups are
+// delimited by equality with delimiter, not by a predicate or a position.
+//
+// Consecutive delimiters produce empty groups, and a leading or trailing
+// delimiter produces an empty group at that end. Empty input returns a
+// single empty group.
+//  SplitOn([]int{1, 2, 0, 3, 0, 4, 5}, 0) -> [[1 2] [3] [4 5]]
+func SplitOn(iterable []int, delimiter int) [][]int {
+	results := [][]int{}
+	current := []int{}
+
+	for _, v := range iterable {
+		if v == delimiter {
+			results = append(results, current)
+			current = []int{}
+		} else {
+			current = append(current, v)
+		}
+	}
+
+	results = append(results, current)
+
+	return results
+}
+
+// JoinWith concatenates iterables, inserting a copy of separator between
+// each pair. This complements
+// Chain, which concatenates with nothing in between.
+//
+// An empty iterables list returns an empty slice; a single iterable is
+// returned as a copy with no separator inserted.
+//  JoinWith([]int{0}, []int{1, 2}, []int{3}, []int{4, 5}) -> [1 2 0 3 0 4 5]
+func JoinWith(separator []int, iterables ...[]int) []int {
+	if len(iterables) == 0 {
+		return []int{}
+	}
+
+	total := 0
+	for _, v := range iterables {
+		total += len(v)
+	}
+	total += len(separator) * (len(iterables) - 1)
+
+	result := make([]int, 0, total)
+	for i, v := range iterables {
+		if i > 0 {
+			result = append(result, separator...)
+		}
+		result = append(result, v...)
+	}
+
+	return result
+}
+
+// detectCycleIterationCap bounds the search performed by DetectCycle so
+// that a function with no cycle within the cap does not loop forever.
+const detectCycleIterationCap = 1000000
+
+// DetectCycle finds the start index and length of a cycle in the
+// sequence start, fn(start), fn(fn(start)), ... using Floyd's
+// tortoise-and-hare algorithm. muIndex is the length of the pre-period
+// (the tail before the cycle begins) and lambdaLength is the cycle
+// length.
+//
+// If no cycle is found within detectCycleIterationCap iterations,
+// found is false and muIndex/lambdaLength are 0.
+func DetectCycle(fn func(int) int, start int) (muIndex, lambdaLength int, found bool) {
+	tortoise := fn(start)
+	hare := fn(fn(start))
+
+	steps := 0
+	for tortoise != hare {
+		if steps >= detectCycleIterationCap {
+			return 0, 0, false
+		}
+		tortoise = fn(tortoise)
+		hare = fn(fn(hare))
+		steps++
+	}
+
+	mu := 0
+	tortoise = start
+	for tortoise != hare {
+		tortoise = fn(tortoise)
+		hare = fn(hare)
+		mu++
+	}
+
+	lambda := 1
+	hare = fn(tortoise)
+	for tortoise != hare {
+		hare = fn(hare)
+		lambda++
+	}
+
+	return mu, lambda, true
+}
+
+// PrefixSuffixSums returns, in one pass each, prefix[i] the sum of
+// elements up to and including index i and suffix[i] the sum from index
+// i to the end. Together they enable O(1) range-sum and split-point
+// queries over iterable.
+//
+// Both outputs match the input length; empty input returns two empty
+// slices.
+//  PrefixSuffixSums([]int{1, 2, 3}) -> prefix=[1 3 6], suffix=[6 5 3]
+func PrefixSuffixSums(iterable []int) (prefix, suffix []int) {
+	prefix = make([]int, len(iterable))
+	suffix = make([]int, len(iterable))
+
+	sum := 0
+	for i, v := range iterable {
+		sum += v
+		prefix[i] = sum
+	}
+
+	sum = 0
+	for i := len(iterable) - 1; i >= 0; i-- {
+		sum += iterable[i]
+		suffix[i] = sum
+	}
+
+	return prefix, suffix
+}
+
+// CombinationsSummingTo returns the r-length combinations of iterable
+// whose elements sum to exactly target, in the same order as
+// Combinations. An index-based recursion builds every r-length
+// combination and keeps those whose sum equals target; branches are not
+// pruned by partial sum.
+//
+// r > len(iterable) returns nil; r == 0 returns [][]int{{}} only if
+// target is 0, and nil otherwise.
+//  CombinationsSummingTo([]int{1, 2, 3, 4, 5}, 2, 6) -> [[1 5] [2 4]]
+func CombinationsSummingTo(iterable []int, r, target int) [][]int {
+	if r > len(iterable) {
+		return nil
+	}
+	if r == 0 {
+		if target == 0 {
+			return [][]int{{}}
+		}
+		return nil
+	}
+
+	results := [][]int{}
+	current := make([]int, 0, r)
+
+	var recurse func(start, remaining, sum int)
+	recurse = func(start, remaining, sum int) {
+		if remaining == 0 {
+			if sum == target {
+				combo := make([]int, len(current))
+				copy(combo, current)
+				results = append(results, combo)
+			}
+			return
+		}
+
+		for i := start; i <= len(iterable)-remaining; i++ {
+			current = append(current, iterable[i])
+			recurse(i+1, remaining-1, sum+iterable[i])
+			current = current[:len(current)-1]
+		}
+	}
+
+	recurse(0, r, 0)
+
+	return results
+}
+
+// Lagged generalizes Pairwise to an arbitrary set of lag offsets for
+// time-series feature engineering: for each position i it returns a
+// tuple of the values at i-lag for every lag in lags, so a lag of 0 is
+// the current value and a positive lag looks earlier in iterable.
+// Positions that fall out of range are filled with fill.
+//
+// The output length equals the input length.
+//  Lagged([]int{10, 20, 30}, []int{0, 1}, -1) -> [[10 -1] [20 10] [30 20]]
+func Lagged(iterable []int, lags []int, fill int) [][]int {
+	results := make([][]int, len(iterable))
+
+	for i := range iterable {
+		tuple := make([]int, len(lags))
+		for j, lag := range lags {
+			idx := i - lag
+			if idx < 0 || idx >= len(iterable) {
+				tuple[j] = fill
+			} else {
+				tuple[j] = iterable[idx]
+			}
+		}
+		results[i] = tuple
+	}
+
+	return results
+}
+
+// Modes returns every value tied for the highest frequency in iterable,
+// sorted in ascending order for deterministic output. Returning all
+// modes rather than an arbitrary single one avoids the ambiguity of
+// ties.
+//
+// Empty input returns an empty slice.
+//  Modes([]int{1, 2, 2, 3, 3}) -> [2 3]
+func Modes(iterable []int) []int {
+	if len(iterable) == 0 {
+		return []int{}
+	}
+
+	counts := map[int]int{}
+	for _, v := range iterable {
+		counts[v]++
+	}
+
+	best := 0
+	for _, c := range counts {
+		if c > best {
+			best = c
+		}
+	}
+
+	results := []int{}
+	for v, c := range counts {
+		if c == best {
+			results = append(results, v)
+		}
+	}
+
+	sort.Ints(results)
+
+	return results
+}
+
+// ProductChan is the streaming counterpart to Product: it emits each
+// tuple of the Cartesian product of args on the returned channel as it
+// is generated, without ever materializing the full [][]int. This is
+// essential when the product is too large to fit in memory.
+//
+// The producing goroutine exits and closes the output channel as soon
+// as every tuple has been emitted or done is closed, whichever comes
+// first. Each emitted slice is a fresh copy safe for the receiver to
+// retain. Any empty arg yields no tuples.
+func ProductChan(done <-chan struct{}, args ...[]int) <-chan []int {
+	out := make(chan []int)
+
+	go func() {
+		defer close(out)
+
+		npools := len(args)
+		if npools == 0 {
+			return
+		}
+		for _, pool := range args {
+			if len(pool) == 0 {
+				return
+			}
+		}
+
+		indices := make([]int, npools)
+
+		for {
+			tuple := make([]int, npools)
+			for i, pool := range args {
+				tuple[i] = pool[indices[i]]
+			}
+
+			select {
+			case out <- tuple:
+			case <-done:
+				return
+			}
+
+			i := npools - 1
+			for ; i >= 0; i-- {
+				indices[i]++
+				if indices[i] == len(args[i]) {
+					indices[i] = 0
+				} else {
+					break
+				}
+			}
+			if i < 0 {
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// gcd returns the greatest common divisor of a and b, both treated as
+// non-negative.
+func gcd(a, b int) int {
+	if a < 0 {
+		a = -a
+	}
+	if b < 0 {
+		b = -b
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// SlidingGCD returns the GCD of each contiguous window of width size in
+// iterable. Because GCD is associative, each window's GCD is computed
+// with the two-stack (min-queue-style) technique in O(n) amortized time
+// rather than recomputing from scratch per window.
+//
+// size exceeding the input length returns an empty slice; size <= 0
+// returns nil.
+//  SlidingGCD([]int{12, 18, 24, 9}, 2) -> [6 6 3]
+func SlidingGCD(iterable []int, size int) []int {
+	if size <= 0 {
+		return nil
+	}
+	if size > len(iterable) {
+		return []int{}
+	}
+
+	type stackEntry struct {
+		value int
+		gcd   int
+	}
+
+	var inStack, outStack []stackEntry
+
+	transfer := func() {
+		for len(inStack) > 0 {
+			top := inStack[len(inStack)-1]
+			inStack = inStack[:len(inStack)-1]
+
+			g := top.value
+			if len(outStack) > 0 {
+				g = gcd(outStack[len(outStack)-1].gcd, top.value)
+			}
+			outStack = append(outStack, stackEntry{value: top.value, gcd: g})
+		}
+	}
+
+	push := func(v int) {
+		g := v
+		if len(inStack) > 0 {
+			g = gcd(inStack[len(inStack)-1].gcd, v)
+		}
+		inStack = append(inStack, stackEntry{value: v, gcd: g})
+	}
+
+	pop := func() {
+		if len(outStack) == 0 {
+			transfer()
+		}
+		outStack = outStack[:len(outStack)-1]
+	}
+
+	windowGCD := func() int {
+		if len(inStack) == 0 {
+			return outStack[len(outStack)-1].gcd
+		}
+		if len(outStack) == 0 {
+			return inStack[len(inStack)-1].gcd
+		}
+		return gcd(outStack[len(outStack)-1].gcd, inStack[len(inStack)-1].gcd)
+	}
+
+	results := []int{}
+
+	for i, v := range iterable {
+		push(v)
+		if i >= size-1 {
+			results = append(results, windowGCD())
+			pop()
+		}
+	}
+
+	return results
+}
+
+// IsRotation reports whether b is some cyclic rotation of a. It checks
+// that b is a contiguous subslice of a concatenated with itself.
+//
+// Equal-length empty slices are rotations of each other.
+//  IsRotation([]int{1, 2, 3, 4}, []int{3, 4, 1, 2}) -> true
+func IsRotation(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	if len(a) == 0 {
+		return true
+	}
+
+	doubled := append(append([]int{}, a...), a...)
+
+	for i := 0; i+len(b) <= len(doubled); i++ {
+		if sliceEqual(doubled[i:i+len(b)], b) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LongestRun scans iterable once and returns the start index and length
+// of the longest contiguous run of elements satisfying predicate. A nil
+// predicate uses the >0 convention, the same default used elsewhere in
+// this package.
+//
+// When no element matches, it returns (-1, 0).
+//  LongestRun(isPositive, []int{1, 2, -1, 3, 4, 5}) -> (3, 3)
+func LongestRun(predicate func(int) bool, iterable []int) (start, length int) {
+	if predicate == nil {
+		predicate = func(x int) bool { return x > 0 }
+	}
+
+	bestStart, bestLength := -1, 0
+	curStart, curLength := -1, 0
+
+	for i, v := range iterable {
+		if predicate(v) {
+			if curLength == 0 {
+				curStart = i
+			}
+			curLength++
+			if curLength > bestLength {
+				bestStart, bestLength = curStart, curLength
+			}
+		} else {
+			curLength = 0
+		}
+	}
+
+	return bestStart, bestLength
+}
+
+// InterleaveSelf produces [x0, f(x0), x1, f(x1), ...] for each x in
+// iterable, a niche but concrete transform useful for complex-number
+// style packing or escape encoding. A nil transform duplicates each
+// element.
+//
+// Empty input returns an empty slice; the result length is always
+// 2*len(iterable).
+//  InterleaveSelf([]int{1, 2, 3}, negate) -> [1 -1 2 -2 3 -3]
+func InterleaveSelf(iterable []int, transform func(int) int) []int {
+	if transform == nil {
+		transform = func(x int) int { return x }
+	}
+
+	result := make([]int, 0, 2*len(iterable))
+	for _, v := range iterable {
+		result = append(result, v, transform(v))
+	}
+
+	return result
+}
+
+// GreedyCover returns the indices of candidates chosen greedily to cover
+// universe: each step picks the candidate covering the most still-
+// uncovered elements, the classic greedy approximation algorithm for set
+// cover over overlapping candidate sets. It stops when universe is
+// fully covered or no remaining candidate adds coverage.
+//
+// An empty universe returns an empty slice.
+func GreedyCover(universe []int, candidates [][]int) []int {
+	if len(universe) == 0 {
+		return []int{}
+	}
+
+	uncovered := map[int]bool{}
+	for _, v := range universe {
+		uncovered[v] = true
+	}
+
+	chosen := []int{}
+
+	for len(uncovered) > 0 {
+		bestIdx := -1
+		bestGain := 0
+
+		for i, candidate := range candidates {
+			gain := 0
+			seen := map[int]bool{}
+			for _, v := range candidate {
+				if uncovered[v] && !seen[v] {
+					gain++
+					seen[v] = true
+				}
+			}
+			if gain > bestGain {
+				bestGain = gain
+				bestIdx = i
+			}
+		}
+
+		if bestIdx == -1 {
+			break
+		}
+
+		chosen = append(chosen, bestIdx)
+		for _, v := range candidates[bestIdx] {
+			delete(uncovered, v)
+		}
+	}
+
+	return chosen
+}
+
+// Compositions enumerates every ordered k-tuple of non-negative integers
+// summing to n, the classic stars-and-bars generator. This is distinct
+// from the unordered IntPartitions generator: order matters here, so
+// [1 2] and [2 1] are both produced. The count is C(n+k-1, k-1).
+//
+// k <= 0 returns nil, except n == 0 which returns [][]int{{}}.
+//  Compositions(3, 2) -> [[0 3] [1 2] [2 1] [3 0]]
+func Compositions(n, k int) [][]int {
+	if k <= 0 {
+		if n == 0 {
+			return [][]int{{}}
+		}
+		return nil
+	}
+
+	results := [][]int{}
+	current := make([]int, k)
+
+	var recurse func(position, remaining int)
+	recurse = func(position, remaining int) {
+		if position == k-1 {
+			current[position] = remaining
+			combo := make([]int, k)
+			copy(combo, current)
+			results = append(results, combo)
+			return
+		}
+
+		for v := 0; v <= remaining; v++ {
+			current[position] = v
+			recurse(position+1, remaining-v)
+		}
+	}
+
+	recurse(0, n)
+
+	return results
+}
+
+// EditDistance returns the Levenshtein distance between a and b: the
+// minimum number of single-element insertions, deletions, or
+// substitutions needed to transform a into b. It uses the standard DP
+// recurrence with the space optimized to two rows of length
+// min(len(a), len(b))+1.
+//
+// An empty a or b returns the length of the other.
+//  EditDistance([]int{1, 2, 3}, []int{1, 3, 4}) -> 2
+func EditDistance(a, b []int) int {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+
+	prev := make([]int, len(a)+1)
+	curr := make([]int, len(a)+1)
+
+	for i := range prev {
+		prev[i] = i
+	}
+
+	for j := 1; j <= len(b); j++ {
+		curr[0] = j
+		for i := 1; i <= len(a); i++ {
+			if a[i-1] == b[j-1] {
+				curr[i] = prev[i-1]
+			} else {
+				curr[i] = 1 + minInt(prev[i-1], minInt(prev[i], curr[i-1]))
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(a)]
+}
+
+// minInt returns the smaller of a and b.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// BalancedSequences returns every Dyck path of n opens (1) and n closes
+// (-1) that stays non-negative in prefix sum, a concrete combinatorial
+// generator useful for exhaustively testing parsers or tree algorithms.
+// The count is the nth Catalan number.
+//
+// n <= 0 returns [][]int{{}}.
+//  BalancedSequences(2) -> [[1 1 -1 -1] [1 -1 1 -1]]
+func BalancedSequences(n int) [][]int {
+	if n <= 0 {
+		return [][]int{{}}
+	}
+
+	results := [][]int{}
+	current := make([]int, 0, 2*n)
+
+	var recurse func(opens, closes int)
+	recurse = func(opens, closes int) {
+		if opens == n && closes == n {
+			seq := make([]int, len(current))
+			copy(seq, current)
+			results = append(results, seq)
+			return
+		}
+
+		if opens < n {
+			current = append(current, 1)
+			recurse(opens+1, closes)
+			current = current[:len(current)-1]
+		}
+
+		if closes < opens {
+			current = append(current, -1)
+			recurse(opens, closes+1)
+			current = current[:len(current)-1]
+		}
+	}
+
+	recurse(0, 0)
+
+	return results
+}
+
+// ZipMap applies op element-wise to a and b up to their shorter common
+// length, so `ZipMap([]int{1,2,3}, []int{4,5,6}, add)` yields `[5 7 9]`.
+// This is more direct than IZip followed by StarMap because it avoids
+// materializing the intermediate [][]int, making it the workhorse for
+// vector arithmetic.
+//
+// A nil op returns nil; unequal lengths truncate to the shorter input,
+// the same convention as IZip.
+func ZipMap(a, b []int, op func(x, y int) int) []int {
+	if op == nil {
+		return nil
+	}
+
+	size := len(a)
+	if len(b) < size {
+		size = len(b)
+	}
+
+	result := make([]int, size)
+	for i := 0; i < size; i++ {
+		result[i] = op(a[i], b[i])
+	}
+
+	return result
+}
+
+// Add returns the element-wise sum of a and b, built on ZipMap.
+func Add(a, b []int) []int {
+	return ZipMap(a, b, func(x, y int) int { return x + y })
+}
+
+// Sub returns the element-wise difference of a and b, built on ZipMap.
+func Sub(a, b []int) []int {
+	return ZipMap(a, b, func(x, y int) int { return x - y })
+}
+
+// Mul returns the element-wise product of a and b, built on ZipMap.
+func Mul(a, b []int) []int {
+	return ZipMap(a, b, func(x, y int) int { return x * y })
+}
+
+// DedupMaxBy keeps, among elements sharing the same key, only the one
+// with the maximum value, emitting results in first-seen key order.
+// This is a common "latest/largest wins per group" reduction built on
+// the bucketing concept.
+//
+// A nil key uses identity, collapsing exact duplicates to one. Empty
+// input returns an empty slice.
+//  DedupMaxBy([]int{3, 1, 5, 2}, mod2) -> [5 2]
+func DedupMaxBy(iterable []int, key func(int) int) []int {
+	if key == nil {
+		key = func(x int) int { return x }
+	}
+
+	best := map[int]int{}
+	order := []int{}
+
+	for _, v := range iterable {
+		k := key(v)
+		if existing, ok := best[k]; !ok {
+			best[k] = v
+			order = append(order, k)
+		} else if v > existing {
+			best[k] = v
+		}
+	}
+
+	result := make([]int, len(order))
+	for i, k := range order {
+		result[i] = best[k]
+	}
+
+	return result
+}
+
+// PadAll pads every input in iterables to the length of the longest
+// with fillvalue and returns them as separate rows. This is distinct
+// from IZipLongest because it keeps the inputs as rows rather than
+// transposing them into tuples, which is what's needed when feeding
+// several now-aligned columns into other column-wise functions.
+//
+// An empty argument list returns nil.
+//  PadAll(0, []int{1, 2, 3}, []int{4, 5}) -> [[1 2 3] [4 5 0]]
+func PadAll(fillvalue int, iterables ...[]int) [][]int {
+	if len(iterables) == 0 {
+		return nil
+	}
+
+	maxLen := 0
+	for _, v := range iterables {
+		if len(v) > maxLen {
+			maxLen = len(v)
+		}
+	}
+
+	results := make([][]int, len(iterables))
+	for i, v := range iterables {
+		padded := make([]int, maxLen)
+		copy(padded, v)
+		for j := len(v); j < maxLen; j++ {
+			padded[j] = fillvalue
+		}
+		results[i] = padded
+	}
+
+	return results
+}
+
+// factorial returns n! for n >= 0.
+func factorial(n int) int {
+	result := 1
+	for i := 2; i <= n; i++ {
+		result *= i
+	}
+	return result
+}
+
+// NthPermutation returns the k-th (0-based) permutation of iterable in
+// lexicographic order using the factorial number system, without
+// generating the permutations before it. This is an O(n^2) unranking
+// algorithm, hugely faster than enumerating Permutations when only one
+// index is needed.
+//
+// iterable should be sorted for the result to be canonical lexicographic
+// order. k outside [0, n!) returns nil.
+//  NthPermutation([]int{1, 2, 3}, 0) -> [1 2 3]
+//  NthPermutation([]int{1, 2, 3}, 5) -> [3 2 1]
+func NthPermutation(iterable []int, k int) []int {
+	n := len(iterable)
+	if k < 0 || k >= factorial(n) {
+		return nil
+	}
+
+	pool := make([]int, n)
+	copy(pool, iterable)
+
+	result := make([]int, 0, n)
+	for i := n; i > 0; i-- {
+		f := factorial(i - 1)
+		idx := k / f
+		k %= f
+
+		result = append(result, pool[idx])
+		pool = append(pool[:idx], pool[idx+1:]...)
+	}
+
+	return result
+}
+
+// PermutationRank returns the 0-based lexicographic rank of iterable
+// among all permutations of its sorted elements, the inverse of
+// NthPermutation. Elements are assumed distinct; behavior with
+// duplicate elements is undefined.
+//  PermutationRank([]int{3, 2, 1}) -> 5
+func PermutationRank(iterable []int) int {
+	n := len(iterable)
+	remaining := make([]int, n)
+	copy(remaining, iterable)
+	sort.Ints(remaining)
+
+	rank := 0
+
+	for i, v := range iterable {
+		idx := 0
+		for j, r := range remaining {
+			if r == v {
+				idx = j
+				break
+			}
+		}
+		rank += idx * factorial(n-i-1)
+		remaining = append(remaining[:idx], remaining[idx+1:]...)
+	}
+
+	return rank
+}
+
+// binomial returns C(n, r), the number of r-subsets of an n-set.
+func binomial(n, r int) int {
+	if r < 0 || r > n {
+		return 0
+	}
+
+	result := 1
+	for i := 0; i < r; i++ {
+		result = result * (n - i) / (i + 1)
+	}
+
+	return result
+}
+
+// NthCombination returns the k-th (0-based) r-combination of iterable in
+// lexicographic order via combinatorial unranking, without enumerating
+// the combinations before it. This is far cheaper than calling
+// Combinations and indexing when n is large, and supports partitioning
+// combinatorial work across machines by index range.
+//
+// k outside [0, C(n,r)) returns nil.
+//  NthCombination([]int{1, 2, 3, 4, 5}, 2, 0) -> [1 2]
+func NthCombination(iterable []int, r, k int) []int {
+	n := len(iterable)
+	total := binomial(n, r)
+	if k < 0 || k >= total {
+		return nil
+	}
+
+	result := make([]int, 0, r)
+	start := 0
+
+	for remaining := r; remaining > 0; remaining-- {
+		for i := start; i < n; i++ {
+			c := binomial(n-i-1, remaining-1)
+			if k < c {
+				result = append(result, iterable[i])
+				start = i + 1
+				break
+			}
+			k -= c
+		}
+	}
+
+	return result
+}
+
+// ChunkByWeight starts a new chunk whenever adding the next element
+// would exceed maxWeight in summed weight, so each chunk's total weight
+// stays within budget, except that a single element whose own weight
+// exceeds maxWeight gets its own (over-budget) chunk. This is a
+// practical batching primitive, such as a payload size limit, not
+// covered by count-based Batched.
+//
+// maxWeight <= 0 returns nil.
+//  ChunkByWeight([]int{3, 1, 2, 5, 1}, id, 4) -> [[3 1] [2] [5] [1]]
+func ChunkByWeight(iterable []int, weight func(int) int, maxWeight int) [][]int {
+	if maxWeight <= 0 {
+		return nil
+	}
+
+	results := [][]int{}
+	current := []int{}
+	currentWeight := 0
+
+	for _, v := range iterable {
+		w := weight(v)
+		if len(current) > 0 && currentWeight+w > maxWeight {
+			results = append(results, current)
+			current = []int{}
+			currentWeight = 0
+		}
+		current = append(current, v)
+		currentWeight += w
+	}
+
+	if len(current) > 0 {
+		results = append(results, current)
+	}
+
+	return results
+}
+
+// CumulativeDistinct returns, at each position, how many distinct values
+// have been seen in iterable up to and including that index, using an
+// incrementally updated set for O(n) behavior. This is a distinct-count
+// analog of the package's cumulative aggregates, useful for tracking
+// vocabulary growth or cardinality over a stream.
+//
+// Empty input returns an empty slice; the output matches the input
+// length.
+//  CumulativeDistinct([]int{1, 2, 1, 3, 2}) -> [1 2 2 3 3]
+func CumulativeDistinct(iterable []int) []int {
+	result := make([]int, len(iterable))
+	seen := map[int]bool{}
+	count := 0
+
+	for i, v := range iterable {
+		if !seen[v] {
+			seen[v] = true
+			count++
+		}
+		result[i] = count
+	}
+
+	return result
+}
+
+// MajorityElement returns the element appearing more than half the time
+// in iterable, found with the Boyer-Moore voting algorithm in O(n) time
+// and O(1) space, more efficient than building a full frequency map via
+// a count-by-value helper when only the majority matters. The boolean
+// is false when no strict majority exists, avoiding a false positive
+// report of a non-majority candidate.
+//
+// Empty input returns (0, false).
+//  MajorityElement([]int{1, 2, 1, 1, 3}) -> (1, true)
+func MajorityElement(iterable []int) (int, bool) {
+	if len(iterable) == 0 {
+		return 0, false
+	}
+
+	candidate := iterable[0]
+	count := 0
+
+	for _, v := range iterable {
+		if count == 0 {
+			candidate = v
+		}
+		if v == candidate {
+			count++
+		} else {
+			count--
+		}
+	}
+
+	occurrences := 0
+	for _, v := range iterable {
+		if v == candidate {
+			occurrences++
+		}
+	}
+
+	if occurrences*2 > len(iterable) {
+		return candidate, true
+	}
+
+	return 0, false
+}
+
+// ProductFiltered generates the Cartesian product of args but keeps
+// only tuples for which predicate returns true. Every tuple of the full
+// product is built and predicate is applied to each complete tuple;
+// partial tuples are not checked or pruned. A nil predicate
+// keeps everything, equivalent to Product.
+//
+// Any empty input returns nil.
+func ProductFiltered(predicate func([]int) bool, args ...[]int) [][]int {
+	if predicate == nil {
+		predicate = func([]int) bool { return true }
+	}
+
+	for _, pool := range args {
+		if len(pool) == 0 {
+			return nil
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	results := [][]int{}
+	current := make([]int, 0, len(args))
+
+	var recurse func(depth int)
+	recurse = func(depth int) {
+		if depth == len(args) {
+			if predicate(current) {
+				tuple := make([]int, len(current))
+				copy(tuple, current)
+				results = append(results, tuple)
+			}
+			return
+		}
+
+		for _, v := range args[depth] {
+			current = append(current, v)
+			recurse(depth + 1)
+			current = current[:len(current)-1]
+		}
+	}
+
+	recurse(0)
+
+	return results
+}
+
+// SlidingMode returns the most frequent value in each window of width
+// size, breaking ties toward the smallest value for determinism. It
+// maintains an incremental frequency map as the window slides rather
+// than recomputing from scratch, alongside the other sliding aggregates
+// such as SlidingDistinctCount and SlidingGCD.
+//
+// size exceeding the input length returns an empty slice; size <= 0
+// returns nil.
+func SlidingMode(iterable []int, size int) []int {
+	if size <= 0 {
+		return nil
+	}
+	if size > len(iterable) {
+		return []int{}
+	}
+
+	counts := map[int]int{}
+	results := []int{}
+
+	modeOf := func() int {
+		best := 0
+		bestCount := 0
+		first := true
+		for v, c := range counts {
+			if first || c > bestCount || (c == bestCount && v < best) {
+				best = v
+				bestCount = c
+				first = false
+			}
+		}
+		return best
+	}
+
+	for i, v := range iterable {
+		counts[v]++
+
+		if i >= size {
+			old := iterable[i-size]
+			counts[old]--
+			if counts[old] == 0 {
+				delete(counts, old)
+			}
+		}
+
+		if i >= size-1 {
+			results = append(results, modeOf())
+		}
+	}
+
+	return results
+}
+
+// ZipReduce fuses IZip and StarMap for performance: for each index up to
+// the shortest length across iterables, it collects the values at that
+// index and applies reduce, returning the scalar results without
+// building the intermediate [][]int that IZip would.
+//
+// A nil reduce returns nil; no iterables also returns nil.
+//  ZipReduce(sum, []int{1, 2, 3}, []int{4, 5, 6}) -> [5 7 9]
+func ZipReduce(reduce func([]int) int, iterables ...[]int) []int {
+	if reduce == nil || len(iterables) == 0 {
+		return nil
+	}
+
+	size := len(iterables[0])
+	for _, v := range iterables[1:] {
+		if len(v) < size {
+			size = len(v)
+		}
+	}
+
+	results := make([]int, size)
+	tuple := make([]int, len(iterables))
+
+	for i := 0; i < size; i++ {
+		for j, v := range iterables {
+			tuple[j] = v[i]
+		}
+		results[i] = reduce(tuple)
+	}
+
+	return results
+}
+
+// SubsetsSummingTo returns every subset (of any size) of iterable whose
+// elements sum to exactly target, built on the same index-based
+// recursion as the powerset generators in this package. Duplicate
+// values are treated as position-distinct: each index is included or
+// excluded independently, so a repeated value can appear in more than
+// one returned subset as separate entries. Negative numbers are
+// supported.
+//
+// An empty result is returned when nothing sums to target; target 0
+// always includes the empty subset.
+//  SubsetsSummingTo([]int{1, 2, 3, 3}, 3) -> [[1 2] [3] [3]]
+func SubsetsSummingTo(iterable []int, target int) [][]int {
+	results := [][]int{}
+	current := []int{}
+
+	var recurse func(start, sum int)
+	recurse = func(start, sum int) {
+		if sum == target {
+			subset := make([]int, len(current))
+			copy(subset, current)
+			results = append(results, subset)
+		}
+
+		for i := start; i < len(iterable); i++ {
+			current = append(current, iterable[i])
+			recurse(i+1, sum+iterable[i])
+			current = current[:len(current)-1]
+		}
+	}
+
+	recurse(0, 0)
+
+	return results
+}
+
+// ProductDiagonals returns every tuple in the Cartesian product of args,
+// ordered by the sum of each tuple's indices into its source slice (ties
+// broken by lexicographic index order), rather than by the standard
+// odometer order that Product uses. Grouping conceptually by index-sum
+// traces out the anti-diagonals of the product grid, giving an ordering
+// of the product space by "distance from origin" — useful for sampling
+// broadly across all inputs before exhausting any one of them.
+//
+// Any empty input returns nil.
+func ProductDiagonals(args ...[]int) [][]int {
+	if len(args) == 0 {
+		return nil
+	}
+	for _, pool := range args {
+		if len(pool) == 0 {
+			return nil
+		}
+	}
+
+	type indexed struct {
+		indices []int
+		tuple   []int
+	}
+
+	all := []indexed{}
+	indices := make([]int, len(args))
+
+	for {
+		tuple := make([]int, len(args))
+		for i, pool := range args {
+			tuple[i] = pool[indices[i]]
+		}
+		idxCopy := make([]int, len(indices))
+		copy(idxCopy, indices)
+		all = append(all, indexed{indices: idxCopy, tuple: tuple})
+
+		i := len(args) - 1
+		for ; i >= 0; i-- {
+			indices[i]++
+			if indices[i] == len(args[i]) {
+				indices[i] = 0
+			} else {
+				break
+			}
+		}
+		if i < 0 {
+			break
+		}
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		sumI, sumJ := 0, 0
+		for _, v := range all[i].indices {
+			sumI += v
+		}
+		for _, v := range all[j].indices {
+			sumJ += v
+		}
+		if sumI != sumJ {
+			return sumI < sumJ
+		}
+		return lexLess(all[i].indices, all[j].indices)
+	})
+
+	results := make([][]int, len(all))
+	for i, e := range all {
+		results[i] = e.tuple
+	}
+
+	return results
+}
+
+// NormalizeSelectors converts an int selector slice to []bool (true
+// where > 0), the same truthiness Compress uses, so callers can validate
+// alignment up front rather than relying on Compress's silent
+// truncation. It errors if len(selectors) != length, which lets callers
+// catch mismatched selector lengths before compressing; the resulting
+// mask is suitable for reuse with CompressBool.
+//
+// An all-zero selector yields an all-false mask.
+func NormalizeSelectors(selectors []int, length int) ([]bool, error) {
+	if len(selectors) != length {
+		return nil, fmt.Errorf("itertools: NormalizeSelectors length %d does not match expected length %d", len(selectors), length)
+	}
+
+	mask := make([]bool, len(selectors))
+	for i, v := range selectors {
+		mask[i] = v > 0
+	}
+
+	return mask, nil
+}
+
+// CrossPairs returns every (ai, bj) pair from the full Cartesian product
+// of a and b as fixed [2]int arrays rather than the variable-length
+// slices Product returns, avoiding per-pair heap allocation for the
+// extremely common two-input product case.
+//
+// Either empty input returns an empty slice.
+//  CrossPairs([]int{1, 2}, []int{3, 4}) -> [[1 3] [1 4] [2 3] [2 4]]
+func CrossPairs(a, b []int) [][2]int {
+	results := make([][2]int, 0, len(a)*len(b))
+
+	for _, ai := range a {
+		for _, bj := range b {
+			results = append(results, [2]int{ai, bj})
+		}
+	}
+
+	return results
+}
+
+// WeightedWindowSum correlates the fixed weight kernel weights across
+// iterable, returning one output per valid window position of width
+// len(weights): the weighted sum of iterable[i:i+len(weights)] against
+// weights. This is effectively valid-mode correlation with explicit
+// integer weights; the kernel is not reversed.
+//
+// Empty weights returns nil; weights longer than the input returns an
+// empty slice.
+//  WeightedWindowSum([]int{1, 2, 3, 4}, []int{1, 2}) -> [5 8 11]
+func WeightedWindowSum(iterable []int, weights []int) []int {
+	if len(weights) == 0 {
+		return nil
+	}
+	if len(weights) > len(iterable) {
+		return []int{}
+	}
+
+	results := make([]int, len(iterable)-len(weights)+1)
+
+	for i := range results {
+		sum := 0
+		for j, w := range weights {
+			sum += iterable[i+j] * w
+		}
+		results[i] = sum
+	}
+
+	return results
+}
+
+// Boustrophedon flattens a 2D slice in snake order: left-to-right on
+// even rows and right-to-left on odd rows. Ragged rows are handled per
+// row by their own lengths. This is a concrete traversal primitive over
+// the [][]int structure the package already produces, useful in
+// toolpath and image-scan contexts, and distinct from the straightforward
+// row-by-row flattening of ChainFromIterable.
+//
+// An empty matrix returns an empty slice.
+//  Boustrophedon([][]int{{1, 2, 3}, {4, 5, 6}}) -> [1 2 3 6 5 4]
+func Boustrophedon(matrix [][]int) []int {
+	result := []int{}
+
+	for i, row := range matrix {
+		if i%2 == 0 {
+			result = append(result, row...)
+		} else {
+			for j := len(row) - 1; j >= 0; j-- {
+				result = append(result, row[j])
+			}
+		}
+	}
+
+	return result
+}
+
+// MinCoveringWindow returns the shortest contiguous window of iterable
+// that contains every element of targets with at least its multiplicity
+// in targets, using a two-pointer sliding approach: the right edge
+// expands until the window satisfies the requirement, then the left
+// edge contracts as far as possible while still satisfying it.
+//
+// Empty targets returns (0, 0, true). When no such window exists, found
+// is false.
+//  MinCoveringWindow([]int{1, 2, 1, 3, 2}, []int{1, 2}) -> (0, 2, true)
+func MinCoveringWindow(iterable []int, targets []int) (start, length int, found bool) {
+	if len(targets) == 0 {
+		return 0, 0, true
+	}
+
+	need := map[int]int{}
+	for _, v := range targets {
+		need[v]++
+	}
+	remaining := len(targets)
+
+	bestStart, bestLength := -1, 0
+	have := map[int]int{}
+
+	left := 0
+	for right, v := range iterable {
+		if need[v] > 0 {
+			if have[v] < need[v] {
+				remaining--
+			}
+		}
+		have[v]++
+
+		for remaining == 0 {
+			if bestLength == 0 || right-left+1 < bestLength {
+				bestStart, bestLength = left, right-left+1
+			}
+
+			leftVal := iterable[left]
+			have[leftVal]--
+			if need[leftVal] > 0 && have[leftVal] < need[leftVal] {
+				remaining++
+			}
+			left++
+		}
+	}
+
+	if bestStart == -1 {
+		return 0, 0, false
+	}
+
+	return bestStart, bestLength, true
+}
+
+// DistinctPowerset returns all value-distinct subsets of iterable,
+// combining the powerset and distinct-combinations ideas: when the
+// input contains repeated values, it collapses the duplicate-laden
+// position-based subsets down to one entry per distinct value
+// combination. It sorts the input once and generates DistinctCombinations
+// for each size from 0 to len(iterable).
+//
+// Results are grouped by subset size in ascending order. An empty input
+// returns [][]int{{}}.
+//  DistinctPowerset([]int{1, 1, 2}) -> [[] [1] [2] [1 1] [1 2] [1 1 2]]
+func DistinctPowerset(iterable []int) [][]int {
+	sorted := make([]int, len(iterable))
+	copy(sorted, iterable)
+	sort.Ints(sorted)
+
+	results := [][]int{}
+	for r := 0; r <= len(sorted); r++ {
+		results = append(results, DistinctCombinations(sorted, r)...)
+	}
+
+	return results
+}
+
+// InversionCount returns the number of pairs (i, j) with i < j but
+// iterable[i] > iterable[j], computed in O(n log n) via a merge-sort-
+// based count rather than the naive O(n^2) comparison of every pair.
+//
+// A sorted slice returns 0 and a reverse-sorted slice returns
+// n(n-1)/2. Empty and single-element inputs return 0.
+//  InversionCount([]int{2, 4, 1, 3, 5}) -> 3
+func InversionCount(iterable []int) int {
+	work := make([]int, len(iterable))
+	copy(work, iterable)
+	buffer := make([]int, len(iterable))
+
+	var mergeCount func(lo, hi int) int
+	mergeCount = func(lo, hi int) int {
+		if hi-lo <= 1 {
+			return 0
+		}
+
+		mid := (lo + hi) / 2
+		count := mergeCount(lo, mid) + mergeCount(mid, hi)
+
+		i, j, k := lo, mid, lo
+		for i < mid && j < hi {
+			if work[i] <= work[j] {
+				buffer[k] = work[i]
+				i++
+			} else {
+				buffer[k] = work[j]
+				j++
+				count += mid - i
+			}
+			k++
+		}
+		for i < mid {
+			buffer[k] = work[i]
+			i++
+			k++
+		}
+		for j < hi {
+			buffer[k] = work[j]
+			j++
+			k++
+		}
+		copy(work[lo:hi], buffer[lo:hi])
+
+		return count
+	}
+
+	return mergeCount(0, len(work))
+}
+
+// SetPartitionsK returns every way to partition iterable into exactly k
+// non-empty subsets, a constrained version of SetPartitions frequently
+// needed for clustering enumeration. The count equals the Stirling
+// number of the second kind S(n,k).
+//
+// k <= 0, k > len(iterable), or an empty input with k > 0 returns nil.
+//  SetPartitionsK([]int{1, 2, 3}, 2) -> the three 2-block partitions of {1, 2, 3}
+func SetPartitionsK(iterable []int, k int) [][][]int {
+	if k <= 0 || k > len(iterable) {
+		return nil
+	}
+
+	var recurse func(remaining []int) [][][]int
+	recurse = func(remaining []int) [][][]int {
+		if len(remaining) == 0 {
+			return [][][]int{{}}
+		}
+
+		first := remaining[0]
+		rest := recurse(remaining[1:])
+
+		results := [][][]int{}
+
+		for _, partition := range rest {
+			for i := range partition {
+				newPartition := make([][]int, len(partition))
+				for j, subset := range partition {
+					newPartition[j] = append([]int{}, subset...)
+				}
+				newPartition[i] = append(newPartition[i], first)
+				results = append(results, newPartition)
+			}
+
+			if len(partition) < k {
+				newPartition := make([][]int, len(partition)+1)
+				copy(newPartition, partition)
+				newPartition[len(partition)] = []int{first}
+				results = append(results, newPartition)
+			}
+		}
+
+		return results
+	}
+
+	all := recurse(iterable)
+
+	results := [][][]int{}
+	for _, partition := range all {
+		if len(partition) == k {
+			results = append(results, partition)
+		}
+	}
+
+	return results
+}
+
+// SlidingWeightedMedian returns, for each window of width size, the
+// weighted median of iterable's elements in that window using the
+// parallel weights slice: values are sorted together with their
+// weights, and the result is the value at which the cumulative weight
+// first reaches half the window's total weight. Ties and even total
+// weight are resolved by this same first-crossing rule, so no
+// averaging between two candidate values is performed.
+//
+// This is implemented as a per-window sort-and-scan, not an incremental
+// structure.
+//
+// size exceeding the input length returns an empty slice; size <= 0 or
+// a weights length not matching iterable's length returns nil.
+func SlidingWeightedMedian(iterable, weights []int, size int) []float64 {
+	if size <= 0 || len(weights) != len(iterable) {
+		return nil
+	}
+	if size > len(iterable) {
+		return []float64{}
+	}
+
+	results := make([]float64, 0, len(iterable)-size+1)
+
+	type pair struct {
+		value  int
+		weight int
+	}
+
+	for start := 0; start+size <= len(iterable); start++ {
+		window := make([]pair, size)
+		total := 0
+		for i := 0; i < size; i++ {
+			window[i] = pair{value: iterable[start+i], weight: weights[start+i]}
+			total += weights[start+i]
+		}
+
+		sort.Slice(window, func(i, j int) bool { return window[i].value < window[j].value })
+
+		cumulative := 0
+		median := 0.0
+		for _, p := range window {
+			cumulative += p.weight
+			if cumulative*2 >= total {
+				median = float64(p.value)
+				break
+			}
+		}
+
+		results = append(results, median)
+	}
+
+	return results
+}
+
+// AccumulateFunc threads op through iterable, matching Python's
+// itertools.accumulate(iterable, func): the first element is emitted
+// unchanged, and each subsequent result is op(previous result, next
+// element). op need not be addition — running max, running product, and
+// gcd chains are common uses.
+//
+// Empty input returns an empty slice.
+//  AccumulateFunc([]int{1, 2, 3, 4}, add) -> [1 3 6 10]
+func AccumulateFunc(iterable []int, op func(a, b int) int) []int {
+	if len(iterable) == 0 {
+		return []int{}
+	}
+
+	result := make([]int, len(iterable))
+	result[0] = iterable[0]
+
+	for i := 1; i < len(iterable); i++ {
+		result[i] = op(result[i-1], iterable[i])
+	}
+
+	return result
+}
+
+// Group pairs a key with the consecutive run of elements that produced
+// it, the result type returned by GroupBy.
+type Group struct {
+	Key   int
+	Items []int
+}
+
+// GroupBy returns consecutive groups of equal keys, matching Python's
+// itertools.groupby: it does not sort first, so non-adjacent runs of the
+// same key produce separate groups. This supports run-length style
+// processing on already-sorted data without writing the bookkeeping by
+// hand.
+//
+// Empty input returns an empty slice.
+//  GroupBy([]int{1, 1, 2, 2, 1}, identity) -> [{1 [1 1]} {2 [2 2]} {1 [1]}]
+func GroupBy(iterable []int, key func(int) int) []Group {
+	results := []Group{}
+
+	for _, v := range iterable {
+		k := key(v)
+		if len(results) > 0 && results[len(results)-1].Key == k {
+			results[len(results)-1].Items = append(results[len(results)-1].Items, v)
+		} else {
+			results = append(results, Group{Key: k, Items: []int{v}})
+		}
+	}
+
+	return results
+}
+
+// StarMap applies fn to each tuple in argLists, unpacking every tuple as
+// fn's variadic arguments, mirroring itertools.starmap. This lets the
+// [][]int output of IZip or Product feed directly into a computation
+// (e.g. applying pow or mul to each tuple) instead of writing manual
+// loops.
+//
+// Empty argLists returns an empty slice.
+//  StarMap(mul, [][]int{{2, 3}, {4, 5}}) -> [6 20]
+func StarMap(fn func(...int) int, argLists [][]int) []int {
+	results := make([]int, len(argLists))
+
+	for i, args := range argLists {
+		results[i] = fn(args...)
+	}
+
+	return results
+}
+
+// Tee returns n independent copies of iterable, matching itertools.tee,
+// so the same source can be fed into several consumers (for example a
+// Pairwise-style consumer and a separate sum) without either one
+// observing mutations made by another. Since this package works over
+// concrete slices rather than lazy iterators, each copy is simply a
+// fresh backing array; a future iterator-based variant could share
+// buffering instead.
+//
+// n <= 0 returns an empty slice of slices.
+func Tee(iterable []int, n int) [][]int {
+	if n <= 0 {
+		return [][]int{}
+	}
+
+	results := make([][]int, n)
+	for i := 0; i < n; i++ {
+		cp := make([]int, len(iterable))
+		copy(cp, iterable)
+		results[i] = cp
+	}
+
+	return results
+}
+
+// ISlice returns the elements of iterable from index start up to (but
+// not including) stop, taking every step-th element, matching
+// itertools.islice semantics so callers can window into a sequence
+// without converting to manual slice arithmetic.
+//
+// step <= 0 returns nil. start and stop are clamped to [0, len(iterable)];
+// start >= stop returns an empty slice.
+//  ISlice([]int{0, 1, 2, 3, 4, 5}, 1, 5, 2) -> [1 3]
+func ISlice(iterable []int, start, stop, step int) []int {
+	if step <= 0 {
+		return nil
+	}
+
+	if start < 0 {
+		start = 0
+	}
+	if stop > len(iterable) {
+		stop = len(iterable)
+	}
+
+	result := []int{}
+	for i := start; i < stop; i += step {
+		result = append(result, iterable[i])
+	}
+
+	return result
+}
+
+// CombinationsWithReplacement returns every r-length combination of
+// elements from iterable, allowing individual elements to repeat, using
+// the same index-based algorithm and ordering guarantees as CPython's
+// itertools.combinations_with_replacement so outputs can be cross-
+// validated against Python scripts.
+func CombinationsWithReplacement(iterable []int, r int) [][]int {
+	if r < 0 {
+		return nil
+	}
+	if len(iterable) == 0 && r > 0 {
+		return nil
+	}
+	if r == 0 {
+		return [][]int{{}}
+	}
+
+	n := len(iterable)
+	indices := make([]int, r)
+
+	current := make([]int, r)
+	for i := range current {
+		current[i] = iterable[0]
+	}
+
+	results := [][]int{append([]int{}, current...)}
+
+	for {
+		i := r - 1
+		for ; i >= 0; i-- {
+			if indices[i] != n-1 {
+				break
+			}
+		}
+		if i < 0 {
+			break
+		}
+
+		next := indices[i] + 1
+		for j := i; j < r; j++ {
+			indices[j] = next
+		}
+
+		for j, idx := range indices {
+			current[j] = iterable[idx]
+		}
+
+		results = append(results, append([]int{}, current...))
+	}
+
+	return results
+}
+
+// Pairwise returns successive overlapping pairs from iterable as fixed
+// [2]int arrays, matching itertools.pairwise. This is the common case of
+// sliding over adjacent elements without abusing IZip(iterable,
+// iterable[1:]) and its heap-allocated []int tuples.
+//
+// Input of length less than 2 returns an empty slice.
+//  Pairwise([]int{1, 2, 3, 4}) -> [[1 2] [2 3] [3 4]]
+func Pairwise(iterable []int) [][2]int {
+	if len(iterable) < 2 {
+		return [][2]int{}
+	}
+
+	results := make([][2]int, len(iterable)-1)
+	for i := 0; i < len(iterable)-1; i++ {
+		results[i] = [2]int{iterable[i], iterable[i+1]}
+	}
+
+	return results
+}
+
+// Batched splits iterable into chunks of n elements, matching Python
+// 3.12's itertools.batched; the last batch is allowed to be shorter than
+// n. This is the common case of batching IDs for bulk operations such as
+// DB inserts.
+//
+// n <= 0 returns nil.
+//  Batched([]int{1, 2, 3, 4, 5}, 2) -> [[1 2] [3 4] [5]]
+func Batched(iterable []int, n int) [][]int {
+	if n <= 0 {
+		return nil
+	}
+
+	results := [][]int{}
+	for i := 0; i < len(iterable); i += n {
+		end := i + n
+		if end > len(iterable) {
+			end = len(iterable)
+		}
+		results = append(results, iterable[i:end])
+	}
+
+	return results
+}
+
+// ProductRepeat computes the Cartesian product of args repeated repeat
+// times, matching Python's product(*args, repeat=n), so generating all
+// n-length digit/base combinations doesn't require the caller to
+// duplicate the pools manually.
+//
+// repeat <= 0 returns [][]int{{}}.
+//  ProductRepeat(2, []int{0, 1}) -> [[0 0] [0 1] [1 0] [1 1]]
+func ProductRepeat(repeat int, args ...[]int) [][]int {
+	if repeat <= 0 {
+		return [][]int{{}}
+	}
+
+	pools := make([][]int, 0, len(args)*repeat)
+	for i := 0; i < repeat; i++ {
+		pools = append(pools, args...)
+	}
+
+	return Product(pools...)
+}
+
+// ChainFromIterable returns a slice consisting of the elements within
+// iterables, flattening a single [][]int argument instead of requiring
+// the caller to spread it across variadic arguments.
+//
+//  ChainFromIterable([][]int{{1, 2}, {3}, {4, 5, 6}}) -> [1 2 3 4 5 6]
+func ChainFromIterable(iterables [][]int) []int {
+	results := []int{}
+
+	for _, v := range iterables {
+		results = append(results, v...)
+	}
+
+	return results
+}
+
+// IMap applies fn element-wise across iterables, stopping at the length
+// of the shortest one, mirroring Python 3's map (Python 2's imap). This
+// lets tabulation patterns built on Count or other generated slices feed
+// straight into a computation without first zipping into tuples.
+//
+// No iterables returns an empty slice.
+//  IMap(add, []int{1, 2, 3}, []int{10, 20, 30}) -> [11 22 33]
+func IMap(fn func(...int) int, iterables ...[]int) []int {
+	if len(iterables) == 0 {
+		return []int{}
+	}
+
+	n := len(iterables[0])
+	for _, v := range iterables[1:] {
+		if len(v) < n {
+			n = len(v)
+		}
+	}
+
+	results := make([]int, n)
+
+	for i := 0; i < n; i++ {
+		args := make([]int, len(iterables))
+		for j, v := range iterables {
+			args[j] = v[i]
+		}
+		results[i] = fn(args...)
+	}
+
+	return results
+}
+
+// CountFrom returns a channel yielding step-spaced values starting at
+// start, forever, matching the real unbounded semantics of
+// itertools.count. Send on done to stop production once the consumer no
+// longer needs values (for example once a value read from the channel
+// fails the consumer's stopping condition).
+//  CountFrom(done, 1, 2) -> 1, 3, 5, 7, ...
+func CountFrom(done <-chan struct{}, start, step int) <-chan int {
+	out := make(chan int)
+
+	go func() {
+		defer close(out)
+
+		for i := start; ; i += step {
+			select {
+			case out <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// CycleForever returns a channel yielding the elements of iterable in
+// order, looping indefinitely, matching the unbounded semantics of
+// itertools.cycle. Send on done to stop production, for example to
+// drive a round-robin scheduling loop terminated by the consumer rather
+// than by a fixed count.
+//
+// An empty iterable yields no values and closes the channel immediately.
+//  CycleForever(done, []int{1, 2, 3}) -> 1, 2, 3, 1, 2, 3, ...
+func CycleForever(done <-chan struct{}, iterable []int) <-chan int {
+	out := make(chan int)
+
+	go func() {
+		defer close(out)
+
+		if len(iterable) == 0 {
+			return
+		}
+
+		for i := 0; ; i = (i + 1) % len(iterable) {
+			select {
+			case out <- iterable[i]:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// RepeatFunc returns a slice with the results of calling fn n times,
+// mirroring the repeatfunc recipe. This avoids writing a manual loop
+// for generating n random values or repeated probes.
+//
+// n < 0 returns nil.
+//  RepeatFunc(rand.Int, 3) -> [.., .., ..]
+func RepeatFunc(fn func() int, n int) []int {
+	if n < 0 {
+		return nil
+	}
+
+	results := make([]int, n)
+
+	for i := range results {
+		results[i] = fn()
+	}
+
+	return results
+}
+
+// RepeatFuncForever returns a channel yielding the results of calling fn
+// indefinitely, the unbounded counterpart to RepeatFunc. Send on done to
+// stop production once the consumer has enough values.
+//  RepeatFuncForever(done, rand.Int) -> .., .., .., ...
+func RepeatFuncForever(done <-chan struct{}, fn func() int) <-chan int {
+	out := make(chan int)
+
+	go func() {
+		defer close(out)
+
+		for {
+			select {
+			case out <- fn():
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// IZipStrict aggregates elements from each of the iterables like IZip,
+// but returns an error if the inputs do not all share the same length,
+// matching Python 3.10's zip(strict=True). Silently truncating to the
+// shortest input has hidden real data bugs, so callers that expect
+// aligned inputs should prefer this over IZip.
+func IZipStrict(iterables ...[]int) ([][]int, error) {
+	if len(iterables) == 0 {
+		return nil, nil
+	}
+
+	n := len(iterables[0])
+	for _, v := range iterables[1:] {
+		if len(v) != n {
+			return nil, fmt.Errorf("itertools: IZipStrict iterables have unequal lengths")
+		}
+	}
+
+	return IZip(iterables...), nil
+}
+
+// ZipWith zips a and b and applies fn in a single pass, so the caller
+// does not need to build [][]int pairs via IZip and loop over them
+// separately. It behaves exactly like ZipMap with its arguments
+// reordered to put fn first, matching the fn-first convention used by
+// IMap and StarMap.
+//
+// A nil fn returns nil; unequal lengths truncate to the shorter input.
+//  ZipWith(add, []int{1, 2, 3}, []int{4, 5, 6}) -> [5 7 9]
+func ZipWith(fn func(a, b int) int, a, b []int) []int {
+	return ZipMap(a, b, fn)
+}
+
+// CompressBool returns a slice of the elements of data for which the
+// corresponding element of selectors is true, matching itertools.compress
+// but taking a first-class []bool mask instead of Compress's ">0" []int
+// convention, so the output of a predicate pass can be used directly.
+//  CompressBool([]int{1, 2, 3, 4}, []bool{true, false, true, false}) -> [1 3]
+func CompressBool(data []int, selectors []bool) []int {
+	n := len(data)
+	if len(selectors) < n {
+		n = len(selectors)
+	}
+
+	results := []int{}
+
+	for i := 0; i < n; i++ {
+		if selectors[i] {
+			results = append(results, data[i])
+		}
+	}
+
+	return results
+}
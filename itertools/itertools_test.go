@@ -4,7 +4,10 @@
 
 package itertools
 
-import "testing"
+import (
+	"sort"
+	"testing"
+)
 
 func TestCount(t *testing.T) {
 	// should return nil
@@ -473,6 +476,24 @@ func TestCombinations(t *testing.T) {
 	}
 }
 
+func TestSetPartitions(t *testing.T) {
+	if v := SetPartitions([]int{}); !slice3dMatch(v, [][][]int{{}}) {
+		t.Errorf("SetPartitions([]) should return [[]], got %v", v)
+	}
+
+	if v := SetPartitions([]int{1}); !slice3dMatch(v, [][][]int{{{1}}}) {
+		t.Errorf("SetPartitions([1]) should return [[[1]]], got %v", v)
+	}
+
+	if v := SetPartitions([]int{1, 2}); len(v) != 2 {
+		t.Errorf("SetPartitions([1 2]) should return 2 partitions, got %v", v)
+	}
+
+	if v := SetPartitions([]int{1, 2, 3}); len(v) != 5 {
+		t.Errorf("SetPartitions([1 2 3]) should return 5 partitions, got %v", v)
+	}
+}
+
 // helper functions
 
 func sliceMatch(a, b []int) bool {
@@ -509,6 +530,1829 @@ func slice2dMatch(a, b [][]int) bool {
 	return true
 }
 
+func TestIntPartitions(t *testing.T) {
+	if v := IntPartitions(-1); v != nil {
+		t.Errorf("IntPartitions(-1) should return nil, got %v", v)
+	}
+
+	if v := IntPartitions(0); !slice2dMatch(v, [][]int{{}}) {
+		t.Errorf("IntPartitions(0) should return [[]], got %v", v)
+	}
+
+	if v := IntPartitions(1); !slice2dMatch(v, [][]int{{1}}) {
+		t.Errorf("IntPartitions(1) should return [[1]], got %v", v)
+	}
+
+	if v := IntPartitions(4); !slice2dMatch(v, [][]int{{4}, {3, 1}, {2, 2}, {2, 1, 1}, {1, 1, 1, 1}}) {
+		t.Errorf("IntPartitions(4) should return [[4] [3 1] [2 2] [2 1 1] [1 1 1 1]], got %v", v)
+	}
+
+	if v := len(IntPartitions(10)); v != 42 {
+		t.Errorf("len(IntPartitions(10)) should return 42, got %v", v)
+	}
+}
+
+func TestDiffApply(t *testing.T) {
+	pairs := [][2][]int{
+		{{1, 2, 3, 4}, {2, 4, 3}},
+		{{1, 2, 3}, {1, 2, 3}},
+		{{1, 2, 3}, {}},
+		{{}, {4, 5, 6}},
+		{{1, 2, 3}, {4, 5, 6}},
+	}
+
+	for _, p := range pairs {
+		a, b := p[0], p[1]
+		if v := Apply(a, Diff(a, b)); !sliceMatch(v, b) {
+			t.Errorf("Apply(%v, Diff(%v, %v)) should return %v, got %v", a, a, b, b, v)
+		}
+	}
+}
+
+func TestLCS(t *testing.T) {
+	// disjoint
+
+	if v := LCS([]int{1, 2, 3}, []int{4, 5, 6}); !sliceMatch(v, []int{}) {
+		t.Errorf("LCS([1 2 3], [4 5 6]) should return [], got %v", v)
+	}
+
+	// identical
+
+	if v := LCS([]int{1, 2, 3}, []int{1, 2, 3}); !sliceMatch(v, []int{1, 2, 3}) {
+		t.Errorf("LCS([1 2 3], [1 2 3]) should return [1 2 3], got %v", v)
+	}
+
+	// partially-overlapping
+
+	if v := LCS([]int{1, 2, 3, 4}, []int{2, 4, 3}); !sliceMatch(v, []int{2, 4}) {
+		t.Errorf("LCS([1 2 3 4], [2 4 3]) should return [2 4], got %v", v)
+	}
+
+	// empty inputs
+
+	if v := LCS([]int{}, []int{1, 2, 3}); !sliceMatch(v, []int{}) {
+		t.Errorf("LCS([], [1 2 3]) should return [], got %v", v)
+	}
+}
+
+func TestIZipTolerant(t *testing.T) {
+	ten := make([]int, 10)
+	nine := make([]int, 9)
+	three := make([]int, 3)
+
+	if _, err := IZipTolerant(1, ten, nine); err != nil {
+		t.Errorf("IZipTolerant(1, len 10, len 9) should not return an error, got %v", err)
+	}
+
+	if _, err := IZipTolerant(1, ten, three); err == nil {
+		t.Errorf("IZipTolerant(1, len 10, len 3) should return an error")
+	}
+
+	v, err := IZipTolerant(1, []int{1, 2, 3}, []int{4, 5, 6})
+	if err != nil {
+		t.Errorf("IZipTolerant(1, [1 2 3], [4 5 6]) should not return an error, got %v", err)
+	}
+
+	if !slice2dMatch(v, [][]int{{1, 4}, {2, 5}, {3, 6}}) {
+		t.Errorf("IZipTolerant(1, [1 2 3], [4 5 6]) should return [[1 4] [2 5] [3 6]], got %v", v)
+	}
+}
+
+func TestDerangements(t *testing.T) {
+	if v := Derangements([]int{}); !slice2dMatch(v, [][]int{{}}) {
+		t.Errorf("Derangements([]) should return [[]], got %v", v)
+	}
+
+	if v := Derangements([]int{1}); v != nil {
+		t.Errorf("Derangements([1]) should return nil, got %v", v)
+	}
+
+	if v := Derangements([]int{1, 2, 3}); !slice2dMatch(v, [][]int{{2, 3, 1}, {3, 1, 2}}) {
+		t.Errorf("Derangements([1 2 3]) should return [[2 3 1] [3 1 2]], got %v", v)
+	}
+
+	if v := len(Derangements([]int{1, 2, 3, 4})); v != 9 {
+		t.Errorf("len(Derangements([1 2 3 4])) should return 9, got %v", v)
+	}
+}
+
+func TestGrayCode(t *testing.T) {
+	if v := GrayCode(-1); v != nil {
+		t.Errorf("GrayCode(-1) should return nil, got %v", v)
+	}
+
+	if v := GrayCode(0); !sliceMatch(v, []int{0}) {
+		t.Errorf("GrayCode(0) should return [0], got %v", v)
+	}
+
+	if v := GrayCode(2); !sliceMatch(v, []int{0, 1, 3, 2}) {
+		t.Errorf("GrayCode(2) should return [0 1 3 2], got %v", v)
+	}
+
+	v := GrayCode(4)
+	if len(v) != 16 {
+		t.Errorf("len(GrayCode(4)) should return 16, got %v", len(v))
+	}
+
+	for i := range v {
+		next := v[(i+1)%len(v)]
+		diff := v[i] ^ next
+		if diff == 0 || diff&(diff-1) != 0 {
+			t.Errorf("GrayCode(4) elements %d and %d should differ by exactly one bit, got %v and %v", i, (i+1)%len(v), v[i], next)
+		}
+	}
+}
+
+func TestChunkedStrict(t *testing.T) {
+	if _, err := ChunkedStrict([]int{1, 2, 3}, 0); err == nil {
+		t.Errorf("ChunkedStrict([1 2 3], 0) should return an error")
+	}
+
+	if _, err := ChunkedStrict([]int{1, 2, 3}, 2); err == nil {
+		t.Errorf("ChunkedStrict([1 2 3], 2) should return an error")
+	}
+
+	v, err := ChunkedStrict([]int{1, 2, 3, 4}, 2)
+	if err != nil {
+		t.Errorf("ChunkedStrict([1 2 3 4], 2) should not return an error, got %v", err)
+	}
+
+	if !slice2dMatch(v, [][]int{{1, 2}, {3, 4}}) {
+		t.Errorf("ChunkedStrict([1 2 3 4], 2) should return [[1 2] [3 4]], got %v", v)
+	}
+}
+
+func TestSlidingArgmax(t *testing.T) {
+	if v := SlidingArgmax([]int{1, 2, 3}, 0); v != nil {
+		t.Errorf("SlidingArgmax([1 2 3], 0) should return nil, got %v", v)
+	}
+
+	if v := SlidingArgmax([]int{1, 2, 3}, -1); v != nil {
+		t.Errorf("SlidingArgmax([1 2 3], -1) should return nil, got %v", v)
+	}
+
+	if v := SlidingArgmax([]int{1, 2, 3}, 5); !sliceMatch(v, []int{}) {
+		t.Errorf("SlidingArgmax([1 2 3], 5) should return [], got %v", v)
+	}
+
+	if v := SlidingArgmax([]int{1, 3, 2, 5, 4}, 3); !sliceMatch(v, []int{1, 3, 3}) {
+		t.Errorf("SlidingArgmax([1 3 2 5 4], 3) should return [1 3 3], got %v", v)
+	}
+}
+
+func TestIsSubsequence(t *testing.T) {
+	if v := IsSubsequence([]int{}, []int{1, 2, 3}); v != true {
+		t.Errorf("IsSubsequence([], [1 2 3]) should return true, got %v", v)
+	}
+
+	if v := IsSubsequence([]int{1, 2, 3}, []int{1, 2, 3}); v != true {
+		t.Errorf("IsSubsequence([1 2 3], [1 2 3]) should return true, got %v", v)
+	}
+
+	if v := IsSubsequence([]int{2, 4}, []int{1, 2, 3, 4}); v != true {
+		t.Errorf("IsSubsequence([2 4], [1 2 3 4]) should return true, got %v", v)
+	}
+
+	if v := IsSubsequence([]int{4, 2}, []int{1, 2, 3, 4}); v != false {
+		t.Errorf("IsSubsequence([4 2], [1 2 3 4]) should return false, got %v", v)
+	}
+}
+
+func TestIsSubslice(t *testing.T) {
+	if v := IsSubslice([]int{}, []int{1, 2, 3}); v != true {
+		t.Errorf("IsSubslice([], [1 2 3]) should return true, got %v", v)
+	}
+
+	if v := IsSubslice([]int{2, 3}, []int{1, 2, 3, 4}); v != true {
+		t.Errorf("IsSubslice([2 3], [1 2 3 4]) should return true, got %v", v)
+	}
+
+	if v := IsSubslice([]int{2, 4}, []int{1, 2, 3, 4}); v != false {
+		t.Errorf("IsSubslice([2 4], [1 2 3 4]) should return false, got %v", v)
+	}
+}
+
+func TestSlidingDistinctCount(t *testing.T) {
+	if v := SlidingDistinctCount([]int{1, 2, 3}, 0); v != nil {
+		t.Errorf("SlidingDistinctCount([1 2 3], 0) should return nil, got %v", v)
+	}
+
+	if v := SlidingDistinctCount([]int{1, 2, 3}, 5); !sliceMatch(v, []int{}) {
+		t.Errorf("SlidingDistinctCount([1 2 3], 5) should return [], got %v", v)
+	}
+
+	if v := SlidingDistinctCount([]int{1, 1, 2, 3, 3}, 3); !sliceMatch(v, []int{2, 3, 2}) {
+		t.Errorf("SlidingDistinctCount([1 1 2 3 3], 3) should return [2 3 2], got %v", v)
+	}
+}
+
+func TestRoundRobinWeighted(t *testing.T) {
+	v := RoundRobinWeighted([]int{1, 2, 3, 4, 5, 6}, []int{7, 8})
+	if len(v) != 8 {
+		t.Errorf("len(RoundRobinWeighted([1..6], [7 8])) should return 8, got %v", len(v))
+	}
+
+	firstIdx, secondIdx := -1, -1
+	for i, x := range v {
+		if x == 7 {
+			firstIdx = i
+		}
+		if x == 8 {
+			secondIdx = i
+		}
+	}
+
+	if secondIdx-firstIdx < 2 {
+		t.Errorf("RoundRobinWeighted([1..6], [7 8]) should space 7 and 8 apart, got %v", v)
+	}
+
+	if v := RoundRobinWeighted([]int{1, 2}, []int{3, 4}); !sliceMatch(v, []int{1, 3, 2, 4}) {
+		t.Errorf("RoundRobinWeighted([1 2], [3 4]) should return [1 3 2 4], got %v", v)
+	}
+}
+
+func TestTakeWhileIndexed(t *testing.T) {
+	if v := TakeWhileIndexed(nil, []int{1, 2, 3}); !sliceMatch(v, []int{}) {
+		t.Errorf("TakeWhileIndexed(nil, [1 2 3]) should return [], got %v", v)
+	}
+
+	predicate := func(i, x int) bool { return x > i }
+	if v := TakeWhileIndexed(predicate, []int{5, 4, 1, 9}); !sliceMatch(v, []int{5, 4}) {
+		t.Errorf("TakeWhileIndexed(predicate, [5 4 1 9]) should return [5 4], got %v", v)
+	}
+}
+
+func TestDropWhileIndexed(t *testing.T) {
+	if v := DropWhileIndexed(nil, []int{1, 2, 3}); !sliceMatch(v, []int{}) {
+		t.Errorf("DropWhileIndexed(nil, [1 2 3]) should return [], got %v", v)
+	}
+
+	predicate := func(i, x int) bool { return x > i }
+	if v := DropWhileIndexed(predicate, []int{5, 4, 1, 9}); !sliceMatch(v, []int{1, 9}) {
+		t.Errorf("DropWhileIndexed(predicate, [5 4 1 9]) should return [1 9], got %v", v)
+	}
+}
+
+func TestCompressFunc(t *testing.T) {
+	if v := CompressFunc([]int{}, nil); !sliceMatch(v, []int{}) {
+		t.Errorf("CompressFunc([], nil) should return [], got %v", v)
+	}
+
+	if v := CompressFunc([]int{1, 2, 3}, nil); !sliceMatch(v, []int{1, 2, 3}) {
+		t.Errorf("CompressFunc([1 2 3], nil) should return [1 2 3], got %v", v)
+	}
+
+	keep := func(i, x int) bool { return i%2 == 0 }
+	if v := CompressFunc([]int{10, 20, 30}, keep); !sliceMatch(v, []int{10, 30}) {
+		t.Errorf("CompressFunc([10 20 30], keep) should return [10 30], got %v", v)
+	}
+}
+
+func TestNecklaces(t *testing.T) {
+	if v := Necklaces([]int{}, 3); v != nil {
+		t.Errorf("Necklaces([], 3) should return nil, got %v", v)
+	}
+
+	if v := Necklaces([]int{0, 1}, 0); v != nil {
+		t.Errorf("Necklaces([0 1], 0) should return nil, got %v", v)
+	}
+
+	if v := len(Necklaces([]int{0, 1}, 3)); v != 4 {
+		t.Errorf("len(Necklaces([0 1], 3)) should return 4, got %v", v)
+	}
+}
+
+func TestGather(t *testing.T) {
+	if v := Gather([]int{10, 20, 30, 40}, []int{}); !sliceMatch(v, []int{}) {
+		t.Errorf("Gather([10 20 30 40], []) should return [], got %v", v)
+	}
+
+	if v := Gather([]int{10, 20, 30, 40}, []int{3, 1, 0}); !sliceMatch(v, []int{40, 20, 10}) {
+		t.Errorf("Gather([10 20 30 40], [3 1 0]) should return [40 20 10], got %v", v)
+	}
+
+	if v := Gather([]int{10, 20, 30}, []int{0, 5, 2}); !sliceMatch(v, []int{10, 30}) {
+		t.Errorf("Gather([10 20 30], [0 5 2]) should return [10 30], got %v", v)
+	}
+}
+
+func TestGatherChecked(t *testing.T) {
+	v, err := GatherChecked([]int{10, 20, 30, 40}, []int{3, 1, 0})
+	if err != nil {
+		t.Errorf("GatherChecked([10 20 30 40], [3 1 0]) should not return an error, got %v", err)
+	}
+
+	if !sliceMatch(v, []int{40, 20, 10}) {
+		t.Errorf("GatherChecked([10 20 30 40], [3 1 0]) should return [40 20 10], got %v", v)
+	}
+
+	if _, err := GatherChecked([]int{10, 20, 30}, []int{5}); err == nil {
+		t.Errorf("GatherChecked([10 20 30], [5]) should return an error")
+	}
+}
+
+func TestScatter(t *testing.T) {
+	if v := Scatter([]int{10, 20}, []int{2, 0}, 4, -1); !sliceMatch(v, []int{20, -1, 10, -1}) {
+		t.Errorf("Scatter([10 20], [2 0], 4, -1) should return [20 -1 10 -1], got %v", v)
+	}
+
+	// duplicate indices: later value wins
+
+	if v := Scatter([]int{10, 20}, []int{0, 0}, 2, -1); !sliceMatch(v, []int{20, -1}) {
+		t.Errorf("Scatter([10 20], [0 0], 2, -1) should return [20 -1], got %v", v)
+	}
+
+	// out-of-range indices are skipped
+
+	if v := Scatter([]int{10, 20}, []int{0, 5}, 2, -1); !sliceMatch(v, []int{10, -1}) {
+		t.Errorf("Scatter([10 20], [0 5], 2, -1) should return [10 -1], got %v", v)
+	}
+}
+
+func TestSymmetricDifference(t *testing.T) {
+	if v := SymmetricDifference(); !sliceMatch(v, []int{}) {
+		t.Errorf("SymmetricDifference() should return [], got %v", v)
+	}
+
+	if v := SymmetricDifference([]int{1, 2, 3}, []int{2, 3, 4}); !sliceMatch(v, []int{1, 4}) {
+		t.Errorf("SymmetricDifference([1 2 3], [2 3 4]) should return [1 4], got %v", v)
+	}
+
+	if v := SymmetricDifference([]int{1, 2, 3}, []int{2, 3, 4}, []int{3, 4, 5}); !sliceMatch(v, []int{1, 3, 5}) {
+		t.Errorf("SymmetricDifference([1 2 3], [2 3 4], [3 4 5]) should return [1 3 5], got %v", v)
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	abs := func(x int) int {
+		if x < 0 {
+			return -x
+		}
+		return x
+	}
+
+	input := []int{-3, 1, -2}
+
+	if v := SortBy(input, abs); !sliceMatch(v, []int{1, -2, -3}) {
+		t.Errorf("SortBy([-3 1 -2], abs) should return [1 -2 -3], got %v", v)
+	}
+
+	if !sliceMatch(input, []int{-3, 1, -2}) {
+		t.Errorf("SortBy should not mutate its input, got %v", input)
+	}
+
+	if v := SortBy([]int{3, 1, 2}, nil); !sliceMatch(v, []int{1, 2, 3}) {
+		t.Errorf("SortBy([3 1 2], nil) should return [1 2 3], got %v", v)
+	}
+
+	if v := SortByDescending([]int{3, 1, 2}, nil); !sliceMatch(v, []int{3, 2, 1}) {
+		t.Errorf("SortByDescending([3 1 2], nil) should return [3 2 1], got %v", v)
+	}
+}
+
+func TestRuns(t *testing.T) {
+	isPositive := func(x int) bool { return x > 0 }
+
+	if v := Runs(isPositive, []int{}); !slice2dMatch(v, [][]int{}) {
+		t.Errorf("Runs(isPositive, []) should return [], got %v", v)
+	}
+
+	if v := Runs(isPositive, []int{-1, -2}); !slice2dMatch(v, [][]int{}) {
+		t.Errorf("Runs(isPositive, [-1 -2]) should return [], got %v", v)
+	}
+
+	if v := Runs(isPositive, []int{1, 2, -1, 3, 4, -2, 5}); !slice2dMatch(v, [][]int{{1, 2}, {3, 4}, {5}}) {
+		t.Errorf("Runs(isPositive, [1 2 -1 3 4 -2 5]) should return [[1 2] [3 4] [5]], got %v", v)
+	}
+
+	if v := Runs(nil, []int{1, -1, 2}); !slice2dMatch(v, [][]int{{1}, {2}}) {
+		t.Errorf("Runs(nil, [1 -1 2]) should return [[1] [2]], got %v", v)
+	}
+}
+
+func TestFork(t *testing.T) {
+	sortAsc := func(v []int) []int {
+		sort.Ints(v)
+		return v
+	}
+
+	reverse := func(v []int) []int {
+		for i, j := 0, len(v)-1; i < j; i, j = i+1, j-1 {
+			v[i], v[j] = v[j], v[i]
+		}
+		return v
+	}
+
+	input := []int{3, 1, 2}
+
+	if v := Fork(input); !slice2dMatch(v, [][]int{}) {
+		t.Errorf("Fork([3 1 2]) should return [], got %v", v)
+	}
+
+	if v := Fork(input, sortAsc, reverse); !slice2dMatch(v, [][]int{{1, 2, 3}, {2, 1, 3}}) {
+		t.Errorf("Fork([3 1 2], sortAsc, reverse) should return [[1 2 3] [2 1 3]], got %v", v)
+	}
+
+	if !sliceMatch(input, []int{3, 1, 2}) {
+		t.Errorf("Fork should not mutate its input, got %v", input)
+	}
+}
+
+func TestEWMA(t *testing.T) {
+	if v := EWMA([]int{1, 2, 3}, -0.1); v != nil {
+		t.Errorf("EWMA([1 2 3], -0.1) should return nil, got %v", v)
+	}
+
+	if v := EWMA([]int{1, 2, 3}, 1.1); v != nil {
+		t.Errorf("EWMA([1 2 3], 1.1) should return nil, got %v", v)
+	}
+
+	if v := EWMA([]int{}, 0.5); len(v) != 0 {
+		t.Errorf("EWMA([], 0.5) should return [], got %v", v)
+	}
+
+	v := EWMA([]int{1, 2, 3, 4}, 0.5)
+	expected := []float64{1, 1.5, 2.25, 3.125}
+	for i, ev := range expected {
+		if v[i] != ev {
+			t.Errorf("EWMA([1 2 3 4], 0.5)[%d] should return %v, got %v", i, ev, v[i])
+		}
+	}
+}
+
+func TestDistinctCombinations(t *testing.T) {
+	if v := DistinctCombinations([]int{1, 2}, 3); v != nil {
+		t.Errorf("DistinctCombinations([1 2], 3) should return nil, got %v", v)
+	}
+
+	if v := DistinctCombinations([]int{1, 1, 2}, 0); !slice2dMatch(v, [][]int{{}}) {
+		t.Errorf("DistinctCombinations([1 1 2], 0) should return [[]], got %v", v)
+	}
+
+	if v := DistinctCombinations([]int{1, 1, 2}, 2); !slice2dMatch(v, [][]int{{1, 1}, {1, 2}}) {
+		t.Errorf("DistinctCombinations([1 1 2], 2) should return [[1 1] [1 2]], got %v", v)
+	}
+
+	if v := DistinctCombinations([]int{1, 2, 3}, 2); !slice2dMatch(v, [][]int{{1, 2}, {1, 3}, {2, 3}}) {
+		t.Errorf("DistinctCombinations([1 2 3], 2) should return [[1 2] [1 3] [2 3]], got %v", v)
+	}
+}
+
+func TestDistinctPermutations(t *testing.T) {
+	if v := DistinctPermutations([]int{}); !slice2dMatch(v, [][]int{{}}) {
+		t.Errorf("DistinctPermutations([]) should return [[]], got %v", v)
+	}
+
+	if v := DistinctPermutations([]int{1, 1, 2}); !slice2dMatch(v, [][]int{{1, 1, 2}, {1, 2, 1}, {2, 1, 1}}) {
+		t.Errorf("DistinctPermutations([1 1 2]) should return [[1 1 2] [1 2 1] [2 1 1]], got %v", v)
+	}
+
+	if v := len(DistinctPermutations([]int{1, 2, 3})); v != 6 {
+		t.Errorf("len(DistinctPermutations([1 2 3])) should return 6, got %v", v)
+	}
+}
+
+func TestSlidingReduceAligned(t *testing.T) {
+	sum := func(v []int) int {
+		s := 0
+		for _, x := range v {
+			s += x
+		}
+		return s
+	}
+
+	if v := SlidingReduceAligned([]int{1, 2, 3}, 0, sum, false); v != nil {
+		t.Errorf("SlidingReduceAligned([1 2 3], 0, sum, false) should return nil, got %v", v)
+	}
+
+	if v := SlidingReduceAligned([]int{1, 2, 3}, 2, nil, false); v != nil {
+		t.Errorf("SlidingReduceAligned([1 2 3], 2, nil, false) should return nil, got %v", v)
+	}
+
+	if v := SlidingReduceAligned([]int{1, 2, 3}, 5, sum, false); !sliceMatch(v, []int{}) {
+		t.Errorf("SlidingReduceAligned([1 2 3], 5, sum, false) should return [], got %v", v)
+	}
+
+	if v := SlidingReduceAligned([]int{1, 2, 3, 4}, 2, sum, false); !sliceMatch(v, []int{3, 5, 7}) {
+		t.Errorf("SlidingReduceAligned([1 2 3 4], 2, sum, false) should return [3 5 7], got %v", v)
+	}
+
+	if v := SlidingReduceAligned([]int{1, 2, 3, 4}, 3, sum, true); len(v) != 4 {
+		t.Errorf("len(SlidingReduceAligned([1 2 3 4], 3, sum, true)) should return 4, got %v", len(v))
+	}
+}
+
+func TestArgSort(t *testing.T) {
+	input := []int{30, 10, 20}
+
+	if v := ArgSort(input); !sliceMatch(v, []int{1, 2, 0}) {
+		t.Errorf("ArgSort([30 10 20]) should return [1 2 0], got %v", v)
+	}
+
+	if !sliceMatch(input, []int{30, 10, 20}) {
+		t.Errorf("ArgSort should not mutate its input, got %v", input)
+	}
+
+	if v := ArgSort([]int{}); !sliceMatch(v, []int{}) {
+		t.Errorf("ArgSort([]) should return [], got %v", v)
+	}
+
+	if v := ArgSort([]int{5, 5, 1}); !sliceMatch(v, []int{2, 0, 1}) {
+		t.Errorf("ArgSort([5 5 1]) should return [2 0 1], got %v", v)
+	}
+}
+
+func TestRank(t *testing.T) {
+	input := []int{30, 10, 20}
+
+	if v := Rank(input); !sliceMatch(v, []int{2, 0, 1}) {
+		t.Errorf("Rank([30 10 20]) should return [2 0 1], got %v", v)
+	}
+
+	if !sliceMatch(input, []int{30, 10, 20}) {
+		t.Errorf("Rank should not mutate its input, got %v", input)
+	}
+
+	if v := Rank([]int{5, 5, 1}); !sliceMatch(v, []int{1, 2, 0}) {
+		t.Errorf("Rank([5 5 1]) should return [1 2 0], got %v", v)
+	}
+}
+
+func TestMapBatched(t *testing.T) {
+	sumEach := func(v []int) []int {
+		s := 0
+		for _, x := range v {
+			s += x
+		}
+		return []int{s}
+	}
+
+	if v := MapBatched([]int{1, 2, 3}, 0, sumEach); v != nil {
+		t.Errorf("MapBatched([1 2 3], 0, sumEach) should return nil, got %v", v)
+	}
+
+	if v := MapBatched([]int{1, 2, 3}, 2, nil); !sliceMatch(v, []int{}) {
+		t.Errorf("MapBatched([1 2 3], 2, nil) should return [], got %v", v)
+	}
+
+	if v := MapBatched([]int{1, 2, 3, 4, 5}, 2, sumEach); !sliceMatch(v, []int{3, 7, 5}) {
+		t.Errorf("MapBatched([1 2 3 4 5], 2, sumEach) should return [3 7 5], got %v", v)
+	}
+}
+
+func TestZipColumns(t *testing.T) {
+	// empty argument list
+	if v := ZipColumns(); v != nil {
+		t.Errorf("ZipColumns() should return nil, got %v", v)
+	}
+
+	// truncates to common minimum length
+	if v := ZipColumns([]int{1, 2, 3, 4}, []int{5, 6, 7}); !slice2dMatch(v, [][]int{{1, 2, 3}, {5, 6, 7}}) {
+		t.Errorf("ZipColumns([1 2 3 4], [5 6 7]) should return [[1 2 3] [5 6 7]], got %v", v)
+	}
+
+	// single input returned as its own column
+	if v := ZipColumns([]int{1, 2, 3}); !slice2dMatch(v, [][]int{{1, 2, 3}}) {
+		t.Errorf("ZipColumns([1 2 3]) should return [[1 2 3]], got %v", v)
+	}
+}
+
+func TestInterleavings(t *testing.T) {
+	// both empty
+	if v := Interleavings([]int{}, []int{}); !slice2dMatch(v, [][]int{{}}) {
+		t.Errorf("Interleavings([], []) should return [[]], got %v", v)
+	}
+
+	// general case
+	if v := Interleavings([]int{1, 2}, []int{3}); !slice2dMatch(v, [][]int{{1, 2, 3}, {1, 3, 2}, {3, 1, 2}}) {
+		t.Errorf("Interleavings([1 2], [3]) should return [[1 2 3] [1 3 2] [3 1 2]], got %v", v)
+	}
+
+	// one side empty
+	if v := Interleavings([]int{1, 2}, []int{}); !slice2dMatch(v, [][]int{{1, 2}}) {
+		t.Errorf("Interleavings([1 2], []) should return [[1 2]], got %v", v)
+	}
+
+	// count matches binomial coefficient
+	if v := Interleavings([]int{1, 2}, []int{3, 4}); len(v) != 6 {
+		t.Errorf("Interleavings([1 2], [3 4]) should return 6 interleavings, got %d", len(v))
+	}
+}
+
+func TestLongestIncreasingSubsequence(t *testing.T) {
+	// empty input
+	if v := LongestIncreasingSubsequence([]int{}); !sliceMatch(v, []int{}) {
+		t.Errorf("LongestIncreasingSubsequence([]) should return [], got %v", v)
+	}
+
+	// general case
+	if v := LongestIncreasingSubsequence([]int{3, 1, 2, 1, 8, 5, 6}); !sliceMatch(v, []int{1, 2, 5, 6}) {
+		t.Errorf("LongestIncreasingSubsequence([3 1 2 1 8 5 6]) should return [1 2 5 6], got %v", v)
+	}
+
+	// strictly decreasing: any single element is a valid LIS
+	if v := LongestIncreasingSubsequence([]int{5, 4, 3, 2, 1}); len(v) != 1 {
+		t.Errorf("LongestIncreasingSubsequence([5 4 3 2 1]) should return a single element, got %v", v)
+	}
+
+	// all equal: strictly increasing means no two equal elements combine
+	if v := LongestIncreasingSubsequence([]int{4, 4, 4, 4}); len(v) != 1 {
+		t.Errorf("LongestIncreasingSubsequence([4 4 4 4]) should return a single element, got %v", v)
+	}
+}
+
+func TestCumulativeMax(t *testing.T) {
+	// empty slice
+	if v := CumulativeMax([]int{}); !sliceMatch(v, []int{}) {
+		t.Errorf("CumulativeMax([]) should return [], got %v", v)
+	}
+
+	// matches correct results
+	if v := CumulativeMax([]int{1, 3, 2, 5, 4}); !sliceMatch(v, []int{1, 3, 3, 5, 5}) {
+		t.Errorf("CumulativeMax([1 3 2 5 4]) should return [1 3 3 5 5], got %v", v)
+	}
+}
+
+func TestCumulativeMin(t *testing.T) {
+	// empty slice
+	if v := CumulativeMin([]int{}); !sliceMatch(v, []int{}) {
+		t.Errorf("CumulativeMin([]) should return [], got %v", v)
+	}
+
+	// matches correct results
+	if v := CumulativeMin([]int{5, 3, 4, 1, 2}); !sliceMatch(v, []int{5, 3, 3, 1, 1}) {
+		t.Errorf("CumulativeMin([5 3 4 1 2]) should return [5 3 3 1 1], got %v", v)
+	}
+}
+
+func TestSplitOn(t *testing.T) {
+	// empty input
+	if v := SplitOn([]int{}, 0); !slice2dMatch(v, [][]int{{}}) {
+		t.Errorf("SplitOn([], 0) should return [[]], got %v", v)
+	}
+
+	// general case
+	if v := SplitOn([]int{1, 2, 0, 3, 0, 4, 5}, 0); !slice2dMatch(v, [][]int{{1, 2}, {3}, {4, 5}}) {
+		t.Errorf("SplitOn([1 2 0 3 0 4 5], 0) should return [[1 2] [3] [4 5]], got %v", v)
+	}
+
+	// consecutive and leading/trailing delimiters produce empty groups
+	if v := SplitOn([]int{0, 1, 0, 0, 2, 0}, 0); !slice2dMatch(v, [][]int{{}, {1}, {}, {2}, {}}) {
+		t.Errorf("SplitOn([0 1 0 0 2 0], 0) should return [[] [1] [] [2] []], got %v", v)
+	}
+}
+
+func TestJoinWith(t *testing.T) {
+	// empty iterables list
+	if v := JoinWith([]int{0}); !sliceMatch(v, []int{}) {
+		t.Errorf("JoinWith([0]) should return [], got %v", v)
+	}
+
+	// single iterable returned with no separator
+	if v := JoinWith([]int{0}, []int{1, 2}); !sliceMatch(v, []int{1, 2}) {
+		t.Errorf("JoinWith([0], [1 2]) should return [1 2], got %v", v)
+	}
+
+	// general case
+	if v := JoinWith([]int{0}, []int{1, 2}, []int{3}, []int{4, 5}); !sliceMatch(v, []int{1, 2, 0, 3, 0, 4, 5}) {
+		t.Errorf("JoinWith([0], [1 2], [3], [4 5]) should return [1 2 0 3 0 4 5], got %v", v)
+	}
+}
+
+func TestDetectCycle(t *testing.T) {
+	// pure cycle starting immediately: 0 -> 1 -> 2 -> 0 -> ...
+	cycleFn := func(x int) int { return (x + 1) % 3 }
+	if mu, lambda, found := DetectCycle(cycleFn, 0); !found || mu != 0 || lambda != 3 {
+		t.Errorf("DetectCycle(cycleFn, 0) should return (0, 3, true), got (%d, %d, %v)", mu, lambda, found)
+	}
+
+	// tail then cycle: 0 -> 1 -> 2 -> 3 -> 2 -> 3 -> ...
+	tailFn := func(x int) int {
+		switch x {
+		case 0:
+			return 1
+		case 1:
+			return 2
+		case 2:
+			return 3
+		default:
+			return 2
+		}
+	}
+	if mu, lambda, found := DetectCycle(tailFn, 0); !found || mu != 2 || lambda != 2 {
+		t.Errorf("DetectCycle(tailFn, 0) should return (2, 2, true), got (%d, %d, %v)", mu, lambda, found)
+	}
+}
+
+func TestPrefixSuffixSums(t *testing.T) {
+	// empty input
+	if p, s := PrefixSuffixSums([]int{}); !sliceMatch(p, []int{}) || !sliceMatch(s, []int{}) {
+		t.Errorf("PrefixSuffixSums([]) should return ([], []), got (%v, %v)", p, s)
+	}
+
+	// general case
+	if p, s := PrefixSuffixSums([]int{1, 2, 3}); !sliceMatch(p, []int{1, 3, 6}) || !sliceMatch(s, []int{6, 5, 3}) {
+		t.Errorf("PrefixSuffixSums([1 2 3]) should return ([1 3 6], [6 5 3]), got (%v, %v)", p, s)
+	}
+}
+
+func TestCombinationsSummingTo(t *testing.T) {
+	// r > len returns nil
+	if v := CombinationsSummingTo([]int{1, 2}, 3, 5); v != nil {
+		t.Errorf("CombinationsSummingTo([1 2], 3, 5) should return nil, got %v", v)
+	}
+
+	// r == 0 with target 0
+	if v := CombinationsSummingTo([]int{1, 2}, 0, 0); !slice2dMatch(v, [][]int{{}}) {
+		t.Errorf("CombinationsSummingTo([1 2], 0, 0) should return [[]], got %v", v)
+	}
+
+	// r == 0 with nonzero target returns nil
+	if v := CombinationsSummingTo([]int{1, 2}, 0, 1); v != nil {
+		t.Errorf("CombinationsSummingTo([1 2], 0, 1) should return nil, got %v", v)
+	}
+
+	// general case
+	if v := CombinationsSummingTo([]int{1, 2, 3, 4, 5}, 2, 6); !slice2dMatch(v, [][]int{{1, 5}, {2, 4}}) {
+		t.Errorf("CombinationsSummingTo([1 2 3 4 5], 2, 6) should return [[1 5] [2 4]], got %v", v)
+	}
+}
+
+func TestLagged(t *testing.T) {
+	// general case
+	if v := Lagged([]int{10, 20, 30}, []int{0, 1}, -1); !slice2dMatch(v, [][]int{{10, -1}, {20, 10}, {30, 20}}) {
+		t.Errorf("Lagged([10 20 30], [0 1], -1) should return [[10 -1] [20 10] [30 20]], got %v", v)
+	}
+
+	// empty input
+	if v := Lagged([]int{}, []int{0, 1}, -1); !slice2dMatch(v, [][]int{}) {
+		t.Errorf("Lagged([], [0 1], -1) should return [], got %v", v)
+	}
+
+	// negative lag looks ahead
+	if v := Lagged([]int{10, 20, 30}, []int{-1}, -1); !slice2dMatch(v, [][]int{{20}, {30}, {-1}}) {
+		t.Errorf("Lagged([10 20 30], [-1], -1) should return [[20] [30] [-1]], got %v", v)
+	}
+}
+
+func TestModes(t *testing.T) {
+	// empty input
+	if v := Modes([]int{}); !sliceMatch(v, []int{}) {
+		t.Errorf("Modes([]) should return [], got %v", v)
+	}
+
+	// single mode
+	if v := Modes([]int{4, 4, 1}); !sliceMatch(v, []int{4}) {
+		t.Errorf("Modes([4 4 1]) should return [4], got %v", v)
+	}
+
+	// multiple tied modes, sorted ascending
+	if v := Modes([]int{1, 2, 2, 3, 3}); !sliceMatch(v, []int{2, 3}) {
+		t.Errorf("Modes([1 2 2 3 3]) should return [2 3], got %v", v)
+	}
+}
+
+func TestProductChan(t *testing.T) {
+	// consumes only a prefix and closes done
+	done := make(chan struct{})
+	ch := ProductChan(done, []int{1, 2}, []int{3, 4})
+
+	first := <-ch
+	if !sliceMatch(first, []int{1, 3}) {
+		t.Errorf("ProductChan first tuple should be [1 3], got %v", first)
+	}
+	close(done)
+
+	// draining after done should eventually close without hanging
+	for range ch {
+	}
+
+	// full product when consumed to completion
+	full := [][]int{}
+	for tuple := range ProductChan(nil, []int{1, 2}, []int{3}) {
+		full = append(full, tuple)
+	}
+	if !slice2dMatch(full, [][]int{{1, 3}, {2, 3}}) {
+		t.Errorf("ProductChan full consumption should yield [[1 3] [2 3]], got %v", full)
+	}
+
+	// empty arg yields no tuples
+	empty := [][]int{}
+	for tuple := range ProductChan(nil, []int{}, []int{1}) {
+		empty = append(empty, tuple)
+	}
+	if len(empty) != 0 {
+		t.Errorf("ProductChan with an empty arg should yield no tuples, got %v", empty)
+	}
+}
+
+func TestSlidingGCD(t *testing.T) {
+	// size <= 0 returns nil
+	if v := SlidingGCD([]int{12, 18, 24, 9}, 0); v != nil {
+		t.Errorf("SlidingGCD([12 18 24 9], 0) should return nil, got %v", v)
+	}
+
+	// size exceeds length returns empty
+	if v := SlidingGCD([]int{12, 18}, 3); !sliceMatch(v, []int{}) {
+		t.Errorf("SlidingGCD([12 18], 3) should return [], got %v", v)
+	}
+
+	// matches correct results
+	if v := SlidingGCD([]int{12, 18, 24, 9}, 2); !sliceMatch(v, []int{6, 6, 3}) {
+		t.Errorf("SlidingGCD([12 18 24 9], 2) should return [6 6 3], got %v", v)
+	}
+}
+
+func TestIsRotation(t *testing.T) {
+	// true case
+	if v := IsRotation([]int{1, 2, 3, 4}, []int{3, 4, 1, 2}); v != true {
+		t.Errorf("IsRotation([1 2 3 4], [3 4 1 2]) should return true, got %v", v)
+	}
+
+	// false case
+	if v := IsRotation([]int{1, 2, 3}, []int{3, 2, 1}); v != false {
+		t.Errorf("IsRotation([1 2 3], [3 2 1]) should return false, got %v", v)
+	}
+
+	// equal-length empty slices
+	if v := IsRotation([]int{}, []int{}); v != true {
+		t.Errorf("IsRotation([], []) should return true, got %v", v)
+	}
+
+	// unequal lengths
+	if v := IsRotation([]int{1, 2, 3}, []int{1, 2}); v != false {
+		t.Errorf("IsRotation([1 2 3], [1 2]) should return false, got %v", v)
+	}
+}
+
+func TestLongestRun(t *testing.T) {
+	isPositive := func(x int) bool { return x > 0 }
+
+	// general case
+	if start, length := LongestRun(isPositive, []int{1, 2, -1, 3, 4, 5}); start != 3 || length != 3 {
+		t.Errorf("LongestRun(isPositive, [1 2 -1 3 4 5]) should return (3, 3), got (%d, %d)", start, length)
+	}
+
+	// no match
+	if start, length := LongestRun(isPositive, []int{-1, -2, -3}); start != -1 || length != 0 {
+		t.Errorf("LongestRun(isPositive, [-1 -2 -3]) should return (-1, 0), got (%d, %d)", start, length)
+	}
+
+	// nil predicate uses the >0 convention
+	if start, length := LongestRun(nil, []int{-1, 2, 3, -4}); start != 1 || length != 2 {
+		t.Errorf("LongestRun(nil, [-1 2 3 -4]) should return (1, 2), got (%d, %d)", start, length)
+	}
+}
+
+func TestInterleaveSelf(t *testing.T) {
+	negate := func(x int) int { return -x }
+
+	// empty input
+	if v := InterleaveSelf([]int{}, negate); !sliceMatch(v, []int{}) {
+		t.Errorf("InterleaveSelf([], negate) should return [], got %v", v)
+	}
+
+	// general case
+	if v := InterleaveSelf([]int{1, 2, 3}, negate); !sliceMatch(v, []int{1, -1, 2, -2, 3, -3}) {
+		t.Errorf("InterleaveSelf([1 2 3], negate) should return [1 -1 2 -2 3 -3], got %v", v)
+	}
+
+	// nil transform duplicates each element
+	if v := InterleaveSelf([]int{1, 2}, nil); !sliceMatch(v, []int{1, 1, 2, 2}) {
+		t.Errorf("InterleaveSelf([1 2], nil) should return [1 1 2 2], got %v", v)
+	}
+}
+
+func TestGreedyCover(t *testing.T) {
+	// empty universe
+	if v := GreedyCover([]int{}, [][]int{{1, 2}}); !sliceMatch(v, []int{}) {
+		t.Errorf("GreedyCover([], [[1 2]]) should return [], got %v", v)
+	}
+
+	// candidate covering the most uncovered elements is chosen first
+	universe := []int{1, 2, 3, 4, 5}
+	candidates := [][]int{{1, 2}, {1, 2, 3, 4}, {4, 5}}
+	if v := GreedyCover(universe, candidates); !sliceMatch(v, []int{1, 2}) {
+		t.Errorf("GreedyCover(universe, candidates) should return [1 2], got %v", v)
+	}
+
+	// no candidate adds coverage beyond what's reachable
+	if v := GreedyCover([]int{1, 2, 99}, [][]int{{1}, {2}}); !sliceMatch(v, []int{0, 1}) {
+		t.Errorf("GreedyCover([1 2 99], [[1] [2]]) should return [0 1], got %v", v)
+	}
+}
+
+func TestCompositions(t *testing.T) {
+	// k <= 0 with n == 0
+	if v := Compositions(0, 0); !slice2dMatch(v, [][]int{{}}) {
+		t.Errorf("Compositions(0, 0) should return [[]], got %v", v)
+	}
+
+	// k <= 0 with n != 0 returns nil
+	if v := Compositions(3, 0); v != nil {
+		t.Errorf("Compositions(3, 0) should return nil, got %v", v)
+	}
+
+	// general case
+	if v := Compositions(3, 2); !slice2dMatch(v, [][]int{{0, 3}, {1, 2}, {2, 1}, {3, 0}}) {
+		t.Errorf("Compositions(3, 2) should return [[0 3] [1 2] [2 1] [3 0]], got %v", v)
+	}
+
+	// count matches C(n+k-1, k-1)
+	if v := Compositions(4, 3); len(v) != 15 {
+		t.Errorf("Compositions(4, 3) should return 15 compositions, got %d", len(v))
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	// identical
+	if v := EditDistance([]int{1, 2, 3}, []int{1, 2, 3}); v != 0 {
+		t.Errorf("EditDistance([1 2 3], [1 2 3]) should return 0, got %d", v)
+	}
+
+	// disjoint
+	if v := EditDistance([]int{1, 2}, []int{3, 4}); v != 2 {
+		t.Errorf("EditDistance([1 2], [3 4]) should return 2, got %d", v)
+	}
+
+	// one deletion plus one insertion
+	if v := EditDistance([]int{1, 2, 3}, []int{1, 3, 4}); v != 2 {
+		t.Errorf("EditDistance([1 2 3], [1 3 4]) should return 2, got %d", v)
+	}
+
+	// empty input returns the other's length
+	if v := EditDistance([]int{}, []int{1, 2, 3}); v != 3 {
+		t.Errorf("EditDistance([], [1 2 3]) should return 3, got %d", v)
+	}
+}
+
+func TestBalancedSequences(t *testing.T) {
+	// n <= 0
+	if v := BalancedSequences(0); !slice2dMatch(v, [][]int{{}}) {
+		t.Errorf("BalancedSequences(0) should return [[]], got %v", v)
+	}
+
+	// general case
+	if v := BalancedSequences(2); !slice2dMatch(v, [][]int{{1, 1, -1, -1}, {1, -1, 1, -1}}) {
+		t.Errorf("BalancedSequences(2) should return [[1 1 -1 -1] [1 -1 1 -1]], got %v", v)
+	}
+
+	// count matches the Catalan numbers
+	if v := BalancedSequences(1); len(v) != 1 {
+		t.Errorf("BalancedSequences(1) should return 1 sequence, got %d", len(v))
+	}
+	if v := BalancedSequences(3); len(v) != 5 {
+		t.Errorf("BalancedSequences(3) should return 5 sequences, got %d", len(v))
+	}
+	if v := BalancedSequences(4); len(v) != 14 {
+		t.Errorf("BalancedSequences(4) should return 14 sequences, got %d", len(v))
+	}
+}
+
+func TestZipMap(t *testing.T) {
+	add := func(x, y int) int { return x + y }
+
+	// nil op returns nil
+	if v := ZipMap([]int{1, 2}, []int{3, 4}, nil); v != nil {
+		t.Errorf("ZipMap([1 2], [3 4], nil) should return nil, got %v", v)
+	}
+
+	// general case
+	if v := ZipMap([]int{1, 2, 3}, []int{4, 5, 6}, add); !sliceMatch(v, []int{5, 7, 9}) {
+		t.Errorf("ZipMap([1 2 3], [4 5 6], add) should return [5 7 9], got %v", v)
+	}
+
+	// unequal lengths truncate
+	if v := ZipMap([]int{1, 2, 3}, []int{4, 5}, add); !sliceMatch(v, []int{5, 7}) {
+		t.Errorf("ZipMap([1 2 3], [4 5], add) should return [5 7], got %v", v)
+	}
+}
+
+func TestAddSubMul(t *testing.T) {
+	if v := Add([]int{1, 2, 3}, []int{4, 5, 6}); !sliceMatch(v, []int{5, 7, 9}) {
+		t.Errorf("Add([1 2 3], [4 5 6]) should return [5 7 9], got %v", v)
+	}
+
+	if v := Sub([]int{4, 5, 6}, []int{1, 2, 3}); !sliceMatch(v, []int{3, 3, 3}) {
+		t.Errorf("Sub([4 5 6], [1 2 3]) should return [3 3 3], got %v", v)
+	}
+
+	if v := Mul([]int{1, 2, 3}, []int{4, 5, 6}); !sliceMatch(v, []int{4, 10, 18}) {
+		t.Errorf("Mul([1 2 3], [4 5 6]) should return [4 10 18], got %v", v)
+	}
+}
+
+func TestDedupMaxBy(t *testing.T) {
+	mod2 := func(x int) int { return x % 2 }
+
+	// empty input
+	if v := DedupMaxBy([]int{}, mod2); !sliceMatch(v, []int{}) {
+		t.Errorf("DedupMaxBy([], mod2) should return [], got %v", v)
+	}
+
+	// general case
+	if v := DedupMaxBy([]int{3, 1, 5, 2}, mod2); !sliceMatch(v, []int{5, 2}) {
+		t.Errorf("DedupMaxBy([3 1 5 2], mod2) should return [5 2], got %v", v)
+	}
+
+	// nil key collapses exact duplicates
+	if v := DedupMaxBy([]int{1, 1, 2}, nil); !sliceMatch(v, []int{1, 2}) {
+		t.Errorf("DedupMaxBy([1 1 2], nil) should return [1 2], got %v", v)
+	}
+}
+
+func TestPadAll(t *testing.T) {
+	// empty argument list
+	if v := PadAll(0); v != nil {
+		t.Errorf("PadAll(0) should return nil, got %v", v)
+	}
+
+	// general case
+	if v := PadAll(0, []int{1, 2, 3}, []int{4, 5}); !slice2dMatch(v, [][]int{{1, 2, 3}, {4, 5, 0}}) {
+		t.Errorf("PadAll(0, [1 2 3], [4 5]) should return [[1 2 3] [4 5 0]], got %v", v)
+	}
+}
+
+func TestNthPermutation(t *testing.T) {
+	// first permutation
+	if v := NthPermutation([]int{1, 2, 3}, 0); !sliceMatch(v, []int{1, 2, 3}) {
+		t.Errorf("NthPermutation([1 2 3], 0) should return [1 2 3], got %v", v)
+	}
+
+	// last permutation
+	if v := NthPermutation([]int{1, 2, 3}, 5); !sliceMatch(v, []int{3, 2, 1}) {
+		t.Errorf("NthPermutation([1 2 3], 5) should return [3 2 1], got %v", v)
+	}
+
+	// middle permutation
+	if v := NthPermutation([]int{1, 2, 3}, 2); !sliceMatch(v, []int{2, 1, 3}) {
+		t.Errorf("NthPermutation([1 2 3], 2) should return [2 1 3], got %v", v)
+	}
+
+	// k out of range
+	if v := NthPermutation([]int{1, 2, 3}, 6); v != nil {
+		t.Errorf("NthPermutation([1 2 3], 6) should return nil, got %v", v)
+	}
+	if v := NthPermutation([]int{1, 2, 3}, -1); v != nil {
+		t.Errorf("NthPermutation([1 2 3], -1) should return nil, got %v", v)
+	}
+}
+
+func TestPermutationRank(t *testing.T) {
+	// matches worked example
+	if v := PermutationRank([]int{3, 2, 1}); v != 5 {
+		t.Errorf("PermutationRank([3 2 1]) should return 5, got %d", v)
+	}
+
+	// first permutation has rank 0
+	if v := PermutationRank([]int{1, 2, 3}); v != 0 {
+		t.Errorf("PermutationRank([1 2 3]) should return 0, got %d", v)
+	}
+
+	// round-trip with NthPermutation
+	sorted := []int{1, 2, 3, 4}
+	for k := 0; k < 24; k++ {
+		perm := NthPermutation(sorted, k)
+		if rank := PermutationRank(perm); rank != k {
+			t.Errorf("PermutationRank(NthPermutation(sorted, %d)) should return %d, got %d", k, k, rank)
+		}
+	}
+}
+
+func TestNthCombination(t *testing.T) {
+	// matches worked example
+	if v := NthCombination([]int{1, 2, 3, 4, 5}, 2, 0); !sliceMatch(v, []int{1, 2}) {
+		t.Errorf("NthCombination([1 2 3 4 5], 2, 0) should return [1 2], got %v", v)
+	}
+
+	// k out of range
+	if v := NthCombination([]int{1, 2, 3}, 2, 3); v != nil {
+		t.Errorf("NthCombination([1 2 3], 2, 3) should return nil, got %v", v)
+	}
+
+	// matches Combinations(...)[k] for small cases
+	all := Combinations([]int{1, 2, 3, 4, 5}, 3)
+	for k, expected := range all {
+		if v := NthCombination([]int{1, 2, 3, 4, 5}, 3, k); !sliceMatch(v, expected) {
+			t.Errorf("NthCombination([1 2 3 4 5], 3, %d) should return %v, got %v", k, expected, v)
+		}
+	}
+}
+
+func TestChunkByWeight(t *testing.T) {
+	id := func(x int) int { return x }
+
+	// maxWeight <= 0 returns nil
+	if v := ChunkByWeight([]int{1, 2, 3}, id, 0); v != nil {
+		t.Errorf("ChunkByWeight([1 2 3], id, 0) should return nil, got %v", v)
+	}
+
+	// general case
+	if v := ChunkByWeight([]int{3, 1, 2, 5, 1}, id, 4); !slice2dMatch(v, [][]int{{3, 1}, {2}, {5}, {1}}) {
+		t.Errorf("ChunkByWeight([3 1 2 5 1], id, 4) should return [[3 1] [2] [5] [1]], got %v", v)
+	}
+}
+
+func TestCumulativeDistinct(t *testing.T) {
+	// empty input
+	if v := CumulativeDistinct([]int{}); !sliceMatch(v, []int{}) {
+		t.Errorf("CumulativeDistinct([]) should return [], got %v", v)
+	}
+
+	// general case
+	if v := CumulativeDistinct([]int{1, 2, 1, 3, 2}); !sliceMatch(v, []int{1, 2, 2, 3, 3}) {
+		t.Errorf("CumulativeDistinct([1 2 1 3 2]) should return [1 2 2 3 3], got %v", v)
+	}
+}
+
+func TestMajorityElement(t *testing.T) {
+	// empty input
+	if v, ok := MajorityElement([]int{}); ok || v != 0 {
+		t.Errorf("MajorityElement([]) should return (0, false), got (%d, %v)", v, ok)
+	}
+
+	// has a majority
+	if v, ok := MajorityElement([]int{1, 2, 1, 1, 3}); !ok || v != 1 {
+		t.Errorf("MajorityElement([1 2 1 1 3]) should return (1, true), got (%d, %v)", v, ok)
+	}
+
+	// no majority
+	if v, ok := MajorityElement([]int{1, 2, 3}); ok || v != 0 {
+		t.Errorf("MajorityElement([1 2 3]) should return (0, false), got (%d, %v)", v, ok)
+	}
+}
+
+func TestProductFiltered(t *testing.T) {
+	allDistinct := func(tuple []int) bool {
+		seen := map[int]bool{}
+		for _, v := range tuple {
+			if seen[v] {
+				return false
+			}
+			seen[v] = true
+		}
+		return true
+	}
+
+	// general case
+	if v := ProductFiltered(allDistinct, []int{1, 2}, []int{1, 2}); !slice2dMatch(v, [][]int{{1, 2}, {2, 1}}) {
+		t.Errorf("ProductFiltered(allDistinct, [1 2], [1 2]) should return [[1 2] [2 1]], got %v", v)
+	}
+
+	// nil predicate keeps everything
+	if v := ProductFiltered(nil, []int{1, 2}); !slice2dMatch(v, [][]int{{1}, {2}}) {
+		t.Errorf("ProductFiltered(nil, [1 2]) should return [[1] [2]], got %v", v)
+	}
+
+	// empty input returns nil
+	if v := ProductFiltered(allDistinct, []int{}, []int{1}); v != nil {
+		t.Errorf("ProductFiltered(allDistinct, [], [1]) should return nil, got %v", v)
+	}
+}
+
+func TestSlidingMode(t *testing.T) {
+	// size <= 0 returns nil
+	if v := SlidingMode([]int{1, 2, 3}, 0); v != nil {
+		t.Errorf("SlidingMode([1 2 3], 0) should return nil, got %v", v)
+	}
+
+	// size exceeds length returns empty
+	if v := SlidingMode([]int{1, 2}, 3); !sliceMatch(v, []int{}) {
+		t.Errorf("SlidingMode([1 2], 3) should return [], got %v", v)
+	}
+
+	// matches correct results as the window's majority value changes
+	if v := SlidingMode([]int{1, 1, 2, 2, 2, 3}, 3); !sliceMatch(v, []int{1, 2, 2, 2}) {
+		t.Errorf("SlidingMode([1 1 2 2 2 3], 3) should return [1 2 2 2], got %v", v)
+	}
+}
+
+func TestZipReduce(t *testing.T) {
+	sum := func(v []int) int {
+		s := 0
+		for _, x := range v {
+			s += x
+		}
+		return s
+	}
+
+	// nil reduce returns nil
+	if v := ZipReduce(nil, []int{1, 2}); v != nil {
+		t.Errorf("ZipReduce(nil, [1 2]) should return nil, got %v", v)
+	}
+
+	// no iterables returns nil
+	if v := ZipReduce(sum); v != nil {
+		t.Errorf("ZipReduce(sum) should return nil, got %v", v)
+	}
+
+	// general case
+	if v := ZipReduce(sum, []int{1, 2, 3}, []int{4, 5, 6}); !sliceMatch(v, []int{5, 7, 9}) {
+		t.Errorf("ZipReduce(sum, [1 2 3], [4 5 6]) should return [5 7 9], got %v", v)
+	}
+}
+
+func TestSubsetsSummingTo(t *testing.T) {
+	// empty result when nothing sums to target
+	if v := SubsetsSummingTo([]int{1, 2}, 100); !slice2dMatch(v, [][]int{}) {
+		t.Errorf("SubsetsSummingTo([1 2], 100) should return [], got %v", v)
+	}
+
+	// target 0 always includes the empty subset
+	if v := SubsetsSummingTo([]int{1, -1}, 0); !slice2dMatch(v, [][]int{{}, {1, -1}}) {
+		t.Errorf("SubsetsSummingTo([1 -1], 0) should return [[] [1 -1]], got %v", v)
+	}
+
+	// duplicate values are position-distinct
+	if v := SubsetsSummingTo([]int{1, 2, 3, 3}, 3); !slice2dMatch(v, [][]int{{1, 2}, {3}, {3}}) {
+		t.Errorf("SubsetsSummingTo([1 2 3 3], 3) should return [[1 2] [3] [3]], got %v", v)
+	}
+}
+
+func TestProductDiagonals(t *testing.T) {
+	// empty input returns nil
+	if v := ProductDiagonals(); v != nil {
+		t.Errorf("ProductDiagonals() should return nil, got %v", v)
+	}
+	if v := ProductDiagonals([]int{}, []int{1}); v != nil {
+		t.Errorf("ProductDiagonals([], [1]) should return nil, got %v", v)
+	}
+
+	// ordered by index-sum, ties broken lexicographically
+	if v := ProductDiagonals([]int{1, 2}, []int{3, 4}); !slice2dMatch(v, [][]int{{1, 3}, {1, 4}, {2, 3}, {2, 4}}) {
+		t.Errorf("ProductDiagonals([1 2], [3 4]) should return [[1 3] [1 4] [2 3] [2 4]], got %v", v)
+	}
+}
+
+func TestNormalizeSelectors(t *testing.T) {
+	// length mismatch errors
+	if _, err := NormalizeSelectors([]int{1, 0}, 3); err == nil {
+		t.Errorf("NormalizeSelectors([1 0], 3) should return an error")
+	}
+
+	// general case
+	mask, err := NormalizeSelectors([]int{1, 0, 2}, 3)
+	if err != nil {
+		t.Errorf("NormalizeSelectors([1 0 2], 3) should not error, got %v", err)
+	}
+	if len(mask) != 3 || mask[0] != true || mask[1] != false || mask[2] != true {
+		t.Errorf("NormalizeSelectors([1 0 2], 3) should return [true false true], got %v", mask)
+	}
+
+	// all-zero selector yields an all-false mask
+	mask, err = NormalizeSelectors([]int{0, 0}, 2)
+	if err != nil || mask[0] != false || mask[1] != false {
+		t.Errorf("NormalizeSelectors([0 0], 2) should return [false false], got %v, %v", mask, err)
+	}
+}
+
+func TestCrossPairs(t *testing.T) {
+	pairsMatch := func(a, b [][2]int) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	// either empty input returns an empty slice
+	if v := CrossPairs([]int{}, []int{1, 2}); !pairsMatch(v, [][2]int{}) {
+		t.Errorf("CrossPairs([], [1 2]) should return [], got %v", v)
+	}
+
+	// general case
+	if v := CrossPairs([]int{1, 2}, []int{3, 4}); !pairsMatch(v, [][2]int{{1, 3}, {1, 4}, {2, 3}, {2, 4}}) {
+		t.Errorf("CrossPairs([1 2], [3 4]) should return [[1 3] [1 4] [2 3] [2 4]], got %v", v)
+	}
+}
+
+func TestWeightedWindowSum(t *testing.T) {
+	// empty weights returns nil
+	if v := WeightedWindowSum([]int{1, 2, 3}, []int{}); v != nil {
+		t.Errorf("WeightedWindowSum([1 2 3], []) should return nil, got %v", v)
+	}
+
+	// weights longer than input returns empty
+	if v := WeightedWindowSum([]int{1, 2}, []int{1, 2, 3}); !sliceMatch(v, []int{}) {
+		t.Errorf("WeightedWindowSum([1 2], [1 2 3]) should return [], got %v", v)
+	}
+
+	// general case
+	if v := WeightedWindowSum([]int{1, 2, 3, 4}, []int{1, 2}); !sliceMatch(v, []int{5, 8, 11}) {
+		t.Errorf("WeightedWindowSum([1 2 3 4], [1 2]) should return [5 8 11], got %v", v)
+	}
+}
+
+func TestBoustrophedon(t *testing.T) {
+	// empty matrix
+	if v := Boustrophedon([][]int{}); !sliceMatch(v, []int{}) {
+		t.Errorf("Boustrophedon([]) should return [], got %v", v)
+	}
+
+	// general case
+	if v := Boustrophedon([][]int{{1, 2, 3}, {4, 5, 6}}); !sliceMatch(v, []int{1, 2, 3, 6, 5, 4}) {
+		t.Errorf("Boustrophedon([[1 2 3] [4 5 6]]) should return [1 2 3 6 5 4], got %v", v)
+	}
+
+	// ragged rows
+	if v := Boustrophedon([][]int{{1, 2}, {3}, {4, 5, 6}}); !sliceMatch(v, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("Boustrophedon([[1 2] [3] [4 5 6]]) should return [1 2 3 4 5 6], got %v", v)
+	}
+}
+
+func TestMinCoveringWindow(t *testing.T) {
+	// empty targets
+	if start, length, found := MinCoveringWindow([]int{1, 2, 3}, []int{}); !found || start != 0 || length != 0 {
+		t.Errorf("MinCoveringWindow([1 2 3], []) should return (0, 0, true), got (%d, %d, %v)", start, length, found)
+	}
+
+	// general case
+	if start, length, found := MinCoveringWindow([]int{1, 2, 1, 3, 2}, []int{1, 2}); !found || start != 0 || length != 2 {
+		t.Errorf("MinCoveringWindow([1 2 1 3 2], [1 2]) should return (0, 2, true), got (%d, %d, %v)", start, length, found)
+	}
+
+	// no covering window exists
+	if _, _, found := MinCoveringWindow([]int{1, 2, 3}, []int{4}); found {
+		t.Errorf("MinCoveringWindow([1 2 3], [4]) should return found=false")
+	}
+
+	// requires multiplicity
+	if start, length, found := MinCoveringWindow([]int{1, 1, 2}, []int{1, 1}); !found || start != 0 || length != 2 {
+		t.Errorf("MinCoveringWindow([1 1 2], [1 1]) should return (0, 2, true), got (%d, %d, %v)", start, length, found)
+	}
+}
+
+func TestDistinctPowerset(t *testing.T) {
+	// empty input
+	if v := DistinctPowerset([]int{}); !slice2dMatch(v, [][]int{{}}) {
+		t.Errorf("DistinctPowerset([]) should return [[]], got %v", v)
+	}
+
+	// collapses duplicate-laden subsets, grouped by size ascending
+	if v := DistinctPowerset([]int{1, 1, 2}); !slice2dMatch(v, [][]int{{}, {1}, {2}, {1, 1}, {1, 2}, {1, 1, 2}}) {
+		t.Errorf("DistinctPowerset([1 1 2]) should return [[] [1] [2] [1 1] [1 2] [1 1 2]], got %v", v)
+	}
+}
+
+func TestInversionCount(t *testing.T) {
+	// empty and single-element inputs
+	if v := InversionCount([]int{}); v != 0 {
+		t.Errorf("InversionCount([]) should return 0, got %d", v)
+	}
+	if v := InversionCount([]int{1}); v != 0 {
+		t.Errorf("InversionCount([1]) should return 0, got %d", v)
+	}
+
+	// general case
+	if v := InversionCount([]int{2, 4, 1, 3, 5}); v != 3 {
+		t.Errorf("InversionCount([2 4 1 3 5]) should return 3, got %d", v)
+	}
+
+	// sorted slice
+	if v := InversionCount([]int{1, 2, 3, 4}); v != 0 {
+		t.Errorf("InversionCount([1 2 3 4]) should return 0, got %d", v)
+	}
+
+	// reverse-sorted slice
+	if v := InversionCount([]int{4, 3, 2, 1}); v != 6 {
+		t.Errorf("InversionCount([4 3 2 1]) should return 6, got %d", v)
+	}
+}
+
+func TestSetPartitionsK(t *testing.T) {
+	// k <= 0
+	if v := SetPartitionsK([]int{1, 2, 3}, 0); v != nil {
+		t.Errorf("SetPartitionsK([1 2 3], 0) should return nil, got %v", v)
+	}
+
+	// k > len
+	if v := SetPartitionsK([]int{1, 2}, 3); v != nil {
+		t.Errorf("SetPartitionsK([1 2], 3) should return nil, got %v", v)
+	}
+
+	// general case
+	if v := SetPartitionsK([]int{1, 2, 3}, 2); !slice3dMatch(v, [][][]int{{{3, 2}, {1}}, {{3, 1}, {2}}, {{3}, {2, 1}}}) {
+		t.Errorf("SetPartitionsK([1 2 3], 2) should return the three 2-block partitions, got %v", v)
+	}
+
+	// count matches the Stirling number of the second kind S(4,2) = 7
+	if v := SetPartitionsK([]int{1, 2, 3, 4}, 2); len(v) != 7 {
+		t.Errorf("SetPartitionsK([1 2 3 4], 2) should return 7 partitions, got %d", len(v))
+	}
+}
+
+func TestSlidingWeightedMedian(t *testing.T) {
+	// size <= 0 returns nil
+	if v := SlidingWeightedMedian([]int{1, 2, 3}, []int{1, 1, 1}, 0); v != nil {
+		t.Errorf("SlidingWeightedMedian([1 2 3], [1 1 1], 0) should return nil, got %v", v)
+	}
+
+	// mismatched weights length returns nil
+	if v := SlidingWeightedMedian([]int{1, 2, 3}, []int{1, 1}, 2); v != nil {
+		t.Errorf("SlidingWeightedMedian([1 2 3], [1 1], 2) should return nil, got %v", v)
+	}
+
+	// size exceeds length returns empty
+	if v := SlidingWeightedMedian([]int{1, 2}, []int{1, 1}, 3); len(v) != 0 {
+		t.Errorf("SlidingWeightedMedian([1 2], [1 1], 3) should return [], got %v", v)
+	}
+
+	// equal weights behaves like a standard median with first-crossing ties
+	v := SlidingWeightedMedian([]int{1, 2, 3, 4}, []int{1, 1, 1, 1}, 3)
+	expected := []float64{2, 3}
+	for i, ev := range expected {
+		if v[i] != ev {
+			t.Errorf("SlidingWeightedMedian([1 2 3 4], [1 1 1 1], 3)[%d] should return %v, got %v", i, ev, v[i])
+		}
+	}
+
+	// heavier weight pulls the weighted median toward it
+	if v := SlidingWeightedMedian([]int{1, 2, 3}, []int{1, 10, 1}, 3); v[0] != 2 {
+		t.Errorf("SlidingWeightedMedian([1 2 3], [1 10 1], 3) should return [2], got %v", v)
+	}
+}
+
+func TestAccumulateFunc(t *testing.T) {
+	add := func(a, b int) int { return a + b }
+	max := func(a, b int) int {
+		if b > a {
+			return b
+		}
+		return a
+	}
+
+	// empty input
+	if v := AccumulateFunc([]int{}, add); !sliceMatch(v, []int{}) {
+		t.Errorf("AccumulateFunc([], add) should return [], got %v", v)
+	}
+
+	// running sum
+	if v := AccumulateFunc([]int{1, 2, 3, 4}, add); !sliceMatch(v, []int{1, 3, 6, 10}) {
+		t.Errorf("AccumulateFunc([1 2 3 4], add) should return [1 3 6 10], got %v", v)
+	}
+
+	// running max
+	if v := AccumulateFunc([]int{1, 3, 2, 5, 4}, max); !sliceMatch(v, []int{1, 3, 3, 5, 5}) {
+		t.Errorf("AccumulateFunc([1 3 2 5 4], max) should return [1 3 3 5 5], got %v", v)
+	}
+}
+
+func groupsMatch(a, b []Group) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Key != b[i].Key || !sliceMatch(a[i].Items, b[i].Items) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGroupBy(t *testing.T) {
+	identity := func(x int) int { return x }
+
+	// empty input
+	if v := GroupBy([]int{}, identity); !groupsMatch(v, []Group{}) {
+		t.Errorf("GroupBy([], identity) should return [], got %v", v)
+	}
+
+	// consecutive runs, non-adjacent runs stay separate
+	if v := GroupBy([]int{1, 1, 2, 2, 1}, identity); !groupsMatch(v, []Group{{1, []int{1, 1}}, {2, []int{2, 2}}, {1, []int{1}}}) {
+		t.Errorf("GroupBy([1 1 2 2 1], identity) should return [{1 [1 1]} {2 [2 2]} {1 [1]}], got %v", v)
+	}
+
+	// key function groups by parity
+	mod2 := func(x int) int { return x % 2 }
+	if v := GroupBy([]int{1, 3, 2, 4, 5}, mod2); !groupsMatch(v, []Group{{1, []int{1, 3}}, {0, []int{2, 4}}, {1, []int{5}}}) {
+		t.Errorf("GroupBy([1 3 2 4 5], mod2) should return [{1 [1 3]} {0 [2 4]} {1 [5]}], got %v", v)
+	}
+}
+
+func TestStarMap(t *testing.T) {
+	mul := func(args ...int) int {
+		product := 1
+		for _, v := range args {
+			product *= v
+		}
+		return product
+	}
+
+	// empty argLists
+	if v := StarMap(mul, [][]int{}); !sliceMatch(v, []int{}) {
+		t.Errorf("StarMap(mul, []) should return [], got %v", v)
+	}
+
+	// general case
+	if v := StarMap(mul, [][]int{{2, 3}, {4, 5}}); !sliceMatch(v, []int{6, 20}) {
+		t.Errorf("StarMap(mul, [[2 3] [4 5]]) should return [6 20], got %v", v)
+	}
+}
+
+func TestTee(t *testing.T) {
+	// n <= 0
+	if v := Tee([]int{1, 2, 3}, 0); !slice2dMatch(v, [][]int{}) {
+		t.Errorf("Tee([1 2 3], 0) should return [], got %v", v)
+	}
+
+	// general case
+	v := Tee([]int{1, 2, 3}, 2)
+	if !slice2dMatch(v, [][]int{{1, 2, 3}, {1, 2, 3}}) {
+		t.Errorf("Tee([1 2 3], 2) should return [[1 2 3] [1 2 3]], got %v", v)
+	}
+
+	// copies are independent
+	v[0][0] = 99
+	if v[1][0] != 1 {
+		t.Errorf("Tee copies should be independent, mutating one affected the other: %v", v)
+	}
+}
+
+func TestISlice(t *testing.T) {
+	// step <= 0 returns nil
+	if v := ISlice([]int{1, 2, 3}, 0, 3, 0); v != nil {
+		t.Errorf("ISlice([1 2 3], 0, 3, 0) should return nil, got %v", v)
+	}
+
+	// general case
+	if v := ISlice([]int{0, 1, 2, 3, 4, 5}, 1, 5, 2); !sliceMatch(v, []int{1, 3}) {
+		t.Errorf("ISlice([0 1 2 3 4 5], 1, 5, 2) should return [1 3], got %v", v)
+	}
+
+	// start >= stop returns empty
+	if v := ISlice([]int{1, 2, 3}, 2, 2, 1); !sliceMatch(v, []int{}) {
+		t.Errorf("ISlice([1 2 3], 2, 2, 1) should return [], got %v", v)
+	}
+
+	// stop clamped to length
+	if v := ISlice([]int{1, 2, 3}, 0, 100, 1); !sliceMatch(v, []int{1, 2, 3}) {
+		t.Errorf("ISlice([1 2 3], 0, 100, 1) should return [1 2 3], got %v", v)
+	}
+}
+
+func TestCombinationsWithReplacement(t *testing.T) {
+	// empty input with r > 0
+	if v := CombinationsWithReplacement([]int{}, 2); v != nil {
+		t.Errorf("CombinationsWithReplacement([], 2) should return nil, got %v", v)
+	}
+
+	// r < 0
+	if v := CombinationsWithReplacement([]int{1, 2}, -1); v != nil {
+		t.Errorf("CombinationsWithReplacement([1 2], -1) should return nil, got %v", v)
+	}
+
+	// r == 0
+	if v := CombinationsWithReplacement([]int{1, 2}, 0); !slice2dMatch(v, [][]int{{}}) {
+		t.Errorf("CombinationsWithReplacement([1 2], 0) should return [[]], got %v", v)
+	}
+
+	// general case, matches CPython ordering
+	if v := CombinationsWithReplacement([]int{1, 2, 3}, 2); !slice2dMatch(v, [][]int{{1, 1}, {1, 2}, {1, 3}, {2, 2}, {2, 3}, {3, 3}}) {
+		t.Errorf("CombinationsWithReplacement([1 2 3], 2) should return [[1 1] [1 2] [1 3] [2 2] [2 3] [3 3]], got %v", v)
+	}
+}
+
+func TestPairwise(t *testing.T) {
+	pairsMatch := func(a, b [][2]int) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	// input of length less than 2
+	if v := Pairwise([]int{1}); !pairsMatch(v, [][2]int{}) {
+		t.Errorf("Pairwise([1]) should return [], got %v", v)
+	}
+
+	// general case
+	if v := Pairwise([]int{1, 2, 3, 4}); !pairsMatch(v, [][2]int{{1, 2}, {2, 3}, {3, 4}}) {
+		t.Errorf("Pairwise([1 2 3 4]) should return [[1 2] [2 3] [3 4]], got %v", v)
+	}
+}
+
+func TestBatched(t *testing.T) {
+	// n <= 0 returns nil
+	if v := Batched([]int{1, 2, 3}, 0); v != nil {
+		t.Errorf("Batched([1 2 3], 0) should return nil, got %v", v)
+	}
+
+	// last batch short
+	if v := Batched([]int{1, 2, 3, 4, 5}, 2); !slice2dMatch(v, [][]int{{1, 2}, {3, 4}, {5}}) {
+		t.Errorf("Batched([1 2 3 4 5], 2) should return [[1 2] [3 4] [5]], got %v", v)
+	}
+}
+
+func TestProductRepeat(t *testing.T) {
+	// repeat <= 0
+	if v := ProductRepeat(0, []int{1, 2}); !slice2dMatch(v, [][]int{{}}) {
+		t.Errorf("ProductRepeat(0, [1 2]) should return [[]], got %v", v)
+	}
+
+	// general case
+	if v := ProductRepeat(2, []int{0, 1}); !slice2dMatch(v, [][]int{{0, 0}, {0, 1}, {1, 0}, {1, 1}}) {
+		t.Errorf("ProductRepeat(2, [0 1]) should return [[0 0] [0 1] [1 0] [1 1]], got %v", v)
+	}
+}
+
+func TestChainFromIterable(t *testing.T) {
+	// general case
+	if v := ChainFromIterable([][]int{{1, 2}, {3}, {4, 5, 6}}); !sliceMatch(v, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("ChainFromIterable([[1 2] [3] [4 5 6]]) should return [1 2 3 4 5 6], got %v", v)
+	}
+
+	// empty slice of slices
+	if v := ChainFromIterable([][]int{}); !sliceMatch(v, []int{}) {
+		t.Errorf("ChainFromIterable([]) should return [], got %v", v)
+	}
+}
+
+func TestIMap(t *testing.T) {
+	add := func(xs ...int) int {
+		return xs[0] + xs[1]
+	}
+
+	// general case
+	if v := IMap(add, []int{1, 2, 3}, []int{10, 20, 30}); !sliceMatch(v, []int{11, 22, 33}) {
+		t.Errorf("IMap(add, [1 2 3], [10 20 30]) should return [11 22 33], got %v", v)
+	}
+
+	// shortest iterable wins
+	if v := IMap(add, []int{1, 2, 3}, []int{10, 20}); !sliceMatch(v, []int{11, 22}) {
+		t.Errorf("IMap(add, [1 2 3], [10 20]) should return [11 22], got %v", v)
+	}
+
+	// no iterables
+	if v := IMap(add); !sliceMatch(v, []int{}) {
+		t.Errorf("IMap(add) should return [], got %v", v)
+	}
+}
+
+func TestCountFrom(t *testing.T) {
+	// consumes only a prefix and closes done
+	done := make(chan struct{})
+	ch := CountFrom(done, 1, 2)
+
+	got := []int{}
+	for i := 0; i < 4; i++ {
+		got = append(got, <-ch)
+	}
+	if !sliceMatch(got, []int{1, 3, 5, 7}) {
+		t.Errorf("CountFrom(1, 2) first 4 values should be [1 3 5 7], got %v", got)
+	}
+	close(done)
+
+	// draining after done should eventually close without hanging
+	for range ch {
+	}
+}
+
+func TestCycleForever(t *testing.T) {
+	// consumes only a prefix and closes done
+	done := make(chan struct{})
+	ch := CycleForever(done, []int{1, 2, 3})
+
+	got := []int{}
+	for i := 0; i < 7; i++ {
+		got = append(got, <-ch)
+	}
+	if !sliceMatch(got, []int{1, 2, 3, 1, 2, 3, 1}) {
+		t.Errorf("CycleForever([1 2 3]) first 7 values should be [1 2 3 1 2 3 1], got %v", got)
+	}
+	close(done)
+
+	// draining after done should eventually close without hanging
+	for range ch {
+	}
+
+	// empty iterable closes immediately
+	empty := []int{}
+	for v := range CycleForever(nil, []int{}) {
+		empty = append(empty, v)
+	}
+	if len(empty) != 0 {
+		t.Errorf("CycleForever([]) should yield no values, got %v", empty)
+	}
+}
+
+func TestRepeatFunc(t *testing.T) {
+	i := 0
+	next := func() int {
+		i++
+		return i
+	}
+
+	// general case
+	if v := RepeatFunc(next, 3); !sliceMatch(v, []int{1, 2, 3}) {
+		t.Errorf("RepeatFunc(next, 3) should return [1 2 3], got %v", v)
+	}
+
+	// n < 0
+	if v := RepeatFunc(next, -1); v != nil {
+		t.Errorf("RepeatFunc(next, -1) should return nil, got %v", v)
+	}
+}
+
+func TestRepeatFuncForever(t *testing.T) {
+	i := 0
+	next := func() int {
+		i++
+		return i
+	}
+
+	// consumes only a prefix and closes done
+	done := make(chan struct{})
+	ch := RepeatFuncForever(done, next)
+
+	got := []int{}
+	for j := 0; j < 3; j++ {
+		got = append(got, <-ch)
+	}
+	if !sliceMatch(got, []int{1, 2, 3}) {
+		t.Errorf("RepeatFuncForever(next) first 3 values should be [1 2 3], got %v", got)
+	}
+	close(done)
+
+	// draining after done should eventually close without hanging
+	for range ch {
+	}
+}
+
+func TestIZipStrict(t *testing.T) {
+	// equal lengths
+	v, err := IZipStrict([]int{1, 2, 3}, []int{4, 5, 6})
+	if err != nil {
+		t.Errorf("IZipStrict([1 2 3], [4 5 6]) should not return an error, got %v", err)
+	}
+	if !slice2dMatch(v, [][]int{{1, 4}, {2, 5}, {3, 6}}) {
+		t.Errorf("IZipStrict([1 2 3], [4 5 6]) should return [[1 4] [2 5] [3 6]], got %v", v)
+	}
+
+	// unequal lengths
+	if _, err := IZipStrict([]int{1, 2, 3}, []int{4, 5}); err == nil {
+		t.Errorf("IZipStrict([1 2 3], [4 5]) should return an error")
+	}
+
+	// no iterables
+	if v, err := IZipStrict(); v != nil || err != nil {
+		t.Errorf("IZipStrict() should return nil, nil, got %v, %v", v, err)
+	}
+}
+
+func TestZipWith(t *testing.T) {
+	add := func(x, y int) int { return x + y }
+
+	// general case
+	if v := ZipWith(add, []int{1, 2, 3}, []int{4, 5, 6}); !sliceMatch(v, []int{5, 7, 9}) {
+		t.Errorf("ZipWith(add, [1 2 3], [4 5 6]) should return [5 7 9], got %v", v)
+	}
+
+	// unequal lengths truncate
+	if v := ZipWith(add, []int{1, 2, 3}, []int{4, 5}); !sliceMatch(v, []int{5, 7}) {
+		t.Errorf("ZipWith(add, [1 2 3], [4 5]) should return [5 7], got %v", v)
+	}
+
+	// nil fn
+	if v := ZipWith(nil, []int{1}, []int{2}); v != nil {
+		t.Errorf("ZipWith(nil, [1], [2]) should return nil, got %v", v)
+	}
+}
+
+func TestCompressBool(t *testing.T) {
+	// general case
+	if v := CompressBool([]int{1, 2, 3, 4}, []bool{true, false, true, false}); !sliceMatch(v, []int{1, 3}) {
+		t.Errorf("CompressBool([1 2 3 4], [true false true false]) should return [1 3], got %v", v)
+	}
+
+	// unequal lengths truncate to shorter
+	if v := CompressBool([]int{1, 2, 3}, []bool{true, true}); !sliceMatch(v, []int{1, 2}) {
+		t.Errorf("CompressBool([1 2 3], [true true]) should return [1 2], got %v", v)
+	}
+}
+
+func slice3dMatch(a, b [][][]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, s := range a {
+		if !slice2dMatch(s, b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func isOdd(v int) bool {
 	if v%2 != 0 {
 		return true
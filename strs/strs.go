@@ -0,0 +1,158 @@
+// Copyright 2012 Nuno Antunes. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package strs specializes a handful of itertools functions to strings
+// and runes, so callers coming from Python's itertools (where string
+// inputs "just work") don't need to convert to []rune and back by hand.
+package strs
+
+// Chain concatenates strs into a single string, the string counterpart
+// to itertools.Chain.
+//  Chain("ab", "cd") -> "abcd"
+func Chain(strs ...string) string {
+	result := ""
+
+	for _, s := range strs {
+		result += s
+	}
+
+	return result
+}
+
+// Product computes the Cartesian product of the input alphabets,
+// returning each combination as a string, so generating fixed-length
+// strings over an alphabet doesn't require manually joining rune
+// slices.
+//
+// Any empty alphabet returns nil.
+//  Product("ab", "01") -> [a0 a1 b0 b1]
+func Product(alphabets ...string) []string {
+	pools := make([][]rune, len(alphabets))
+	for i, a := range alphabets {
+		pools[i] = []rune(a)
+	}
+
+	npools := len(pools)
+	indices := make([]int, npools)
+
+	result := make([]rune, npools)
+	for i := range result {
+		if len(pools[i]) == 0 {
+			return nil
+		}
+		result[i] = pools[i][0]
+	}
+
+	results := []string{string(result)}
+
+	for {
+		i := npools - 1
+		for ; i >= 0; i-- {
+			indices[i]++
+			if indices[i] < len(pools[i]) {
+				break
+			}
+			indices[i] = 0
+		}
+
+		if i < 0 {
+			return results
+		}
+
+		tuple := make([]rune, npools)
+		for j, pool := range pools {
+			tuple[j] = pool[indices[j]]
+		}
+
+		results = append(results, string(tuple))
+	}
+}
+
+// Permutations returns successive r length permutations of the runes of
+// s, each returned as a string.
+//
+// Elements are treated as unique based on their position, not their
+// value, matching itertools.Permutations.
+//  Permutations("abc", 2) -> [ab ac ba bc ca cb]
+func Permutations(s string, r int) []string {
+	pool := []rune(s)
+	n := len(pool)
+
+	if r > n || r == 0 {
+		return nil
+	}
+
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+
+	cycles := make([]int, r)
+	for i := range cycles {
+		cycles[i] = n - i
+	}
+
+	result := make([]rune, r)
+	for i, el := range indices[:r] {
+		result[i] = pool[el]
+	}
+
+	results := []string{string(result)}
+
+	for n > 0 {
+		i := r - 1
+		for ; i >= 0; i-- {
+			cycles[i]--
+			if cycles[i] == 0 {
+				index := indices[i]
+				for j := i; j < n-1; j++ {
+					indices[j] = indices[j+1]
+				}
+				indices[n-1] = index
+				cycles[i] = n - i
+			} else {
+				j := cycles[i]
+				indices[i], indices[n-j] = indices[n-j], indices[i]
+
+				result := make([]rune, r)
+				for k := 0; k < r; k++ {
+					result[k] = pool[indices[k]]
+				}
+
+				results = append(results, string(result))
+
+				break
+			}
+		}
+
+		if i < 0 {
+			return results
+		}
+	}
+
+	return nil
+}
+
+// Compress returns the runes of data for which the corresponding
+// element of selectors is true, the string/rune counterpart to
+// itertools.CompressBool.
+//  Compress("abcd", []bool{true, false, true, false}) -> "ac"
+func Compress(data string, selectors []bool) string {
+	runes := []rune(data)
+
+	n := len(runes)
+	if len(selectors) < n {
+		n = len(selectors)
+	}
+
+	result := make([]rune, 0, n)
+
+	for i := 0; i < n; i++ {
+		if selectors[i] {
+			result = append(result, runes[i])
+		}
+	}
+
+	return string(result)
+}
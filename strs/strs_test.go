@@ -0,0 +1,59 @@
+// Copyright 2012 Nuno Antunes. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package strs
+
+import "testing"
+
+func TestChain(t *testing.T) {
+	// general case
+	if v := Chain("ab", "cd"); v != "abcd" {
+		t.Errorf(`Chain("ab", "cd") should return "abcd", got %q`, v)
+	}
+}
+
+func TestProduct(t *testing.T) {
+	// general case
+	if v := Product("ab", "01"); !sliceMatch(v, []string{"a0", "a1", "b0", "b1"}) {
+		t.Errorf(`Product("ab", "01") should return [a0 a1 b0 b1], got %v`, v)
+	}
+
+	// empty alphabet
+	if v := Product("ab", ""); v != nil {
+		t.Errorf(`Product("ab", "") should return nil, got %v`, v)
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	// general case
+	if v := Permutations("abc", 2); !sliceMatch(v, []string{"ab", "ac", "ba", "bc", "ca", "cb"}) {
+		t.Errorf(`Permutations("abc", 2) should return [ab ac ba bc ca cb], got %v`, v)
+	}
+
+	// r > len(s)
+	if v := Permutations("ab", 3); v != nil {
+		t.Errorf(`Permutations("ab", 3) should return nil, got %v`, v)
+	}
+}
+
+func TestCompress(t *testing.T) {
+	// general case
+	if v := Compress("abcd", []bool{true, false, true, false}); v != "ac" {
+		t.Errorf(`Compress("abcd", [true false true false]) should return "ac", got %q`, v)
+	}
+}
+
+func sliceMatch(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
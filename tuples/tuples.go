@@ -0,0 +1,66 @@
+// Copyright 2012 Nuno Antunes. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package tuples provides lightweight, generic tuple types shared by
+// the heterogeneous parts of the v2 API (Zip2, Zip3, and the planned
+// Enumerate/GroupBy), so downstream code has one consistent shape to
+// hold a fixed-size group of differently typed values.
+package tuples
+
+// Pair is a generic 2-tuple.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// NewPair constructs a Pair from its two elements.
+//
+//  NewPair(1, "a") -> {1 a}
+func NewPair[A, B any](a A, b B) Pair[A, B] {
+	return Pair[A, B]{First: a, Second: b}
+}
+
+// Swap returns a new Pair with the elements reversed.
+//
+//  NewPair(1, "a").Swap() -> {a 1}
+func (p Pair[A, B]) Swap() Pair[B, A] {
+	return Pair[B, A]{First: p.Second, Second: p.First}
+}
+
+// ToArray converts p to a [2]any, for code that needs a uniform
+// representation regardless of the underlying element types.
+func (p Pair[A, B]) ToArray() [2]any {
+	return [2]any{p.First, p.Second}
+}
+
+// PairFromArray builds a Pair[any, any] from a [2]any, the inverse of
+// ToArray. Callers that know the concrete element types should type
+// assert the result's fields rather than calling this directly.
+func PairFromArray(arr [2]any) Pair[any, any] {
+	return Pair[any, any]{First: arr[0], Second: arr[1]}
+}
+
+// Triple is a generic 3-tuple.
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// NewTriple constructs a Triple from its three elements.
+func NewTriple[A, B, C any](a A, b B, c C) Triple[A, B, C] {
+	return Triple[A, B, C]{First: a, Second: b, Third: c}
+}
+
+// ToArray converts t to a [3]any, for code that needs a uniform
+// representation regardless of the underlying element types.
+func (t Triple[A, B, C]) ToArray() [3]any {
+	return [3]any{t.First, t.Second, t.Third}
+}
+
+// TripleFromArray builds a Triple[any, any, any] from a [3]any, the
+// inverse of ToArray.
+func TripleFromArray(arr [3]any) Triple[any, any, any] {
+	return Triple[any, any, any]{First: arr[0], Second: arr[1], Third: arr[2]}
+}
@@ -0,0 +1,48 @@
+// Copyright 2012 Nuno Antunes. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tuples
+
+import "testing"
+
+func TestPair(t *testing.T) {
+	p := NewPair(1, "a")
+
+	// constructor
+	if p.First != 1 || p.Second != "a" {
+		t.Errorf(`NewPair(1, "a") should be {1 a}, got %v`, p)
+	}
+
+	// Swap
+	if s := p.Swap(); s.First != "a" || s.Second != 1 {
+		t.Errorf(`NewPair(1, "a").Swap() should be {a 1}, got %v`, s)
+	}
+
+	// ToArray / PairFromArray round-trip
+	arr := p.ToArray()
+	if arr != [2]any{1, "a"} {
+		t.Errorf(`NewPair(1, "a").ToArray() should be [1 a], got %v`, arr)
+	}
+	if back := PairFromArray(arr); back.First != 1 || back.Second != "a" {
+		t.Errorf("PairFromArray(%v) should round-trip to {1 a}, got %v", arr, back)
+	}
+}
+
+func TestTriple(t *testing.T) {
+	tr := NewTriple(1, "a", true)
+
+	// constructor
+	if tr.First != 1 || tr.Second != "a" || tr.Third != true {
+		t.Errorf(`NewTriple(1, "a", true) should be {1 a true}, got %v`, tr)
+	}
+
+	// ToArray / TripleFromArray round-trip
+	arr := tr.ToArray()
+	if arr != [3]any{1, "a", true} {
+		t.Errorf(`NewTriple(1, "a", true).ToArray() should be [1 a true], got %v`, arr)
+	}
+	if back := TripleFromArray(arr); back.First != 1 || back.Second != "a" || back.Third != true {
+		t.Errorf("TripleFromArray(%v) should round-trip to {1 a true}, got %v", arr, back)
+	}
+}
@@ -0,0 +1,498 @@
+// Copyright 2012 Nuno Antunes. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package v2 is a generic, type-parameterized port of Python's itertools
+// module over arbitrary element types. It covers a subset of the int-only
+// itertools package's functions; the original itertools package is kept
+// as-is for compatibility and holds the functions not ported here.
+package v2
+
+import "github.com/ntns/goitertools/tuples"
+
+// Chain returns a slice consisting of the elements within iterables.
+//
+// Used for treating consecutive sequences as a single sequence.
+//
+//  Chain([]int{1, 2, 3}, []int{4, 5, 6}) -> [1 2 3 4 5 6]
+func Chain[T any](iterables ...[]T) []T {
+	results := []T{}
+
+	for _, v := range iterables {
+		results = append(results, v...)
+	}
+
+	return results
+}
+
+// Cycle returns a slice with values from iterable, repeating elements
+// until n elements can be returned.
+//
+//  Cycle([]int{1, 2, 3, 4}, 6) -> [1 2 3 4 1 2]
+func Cycle[T any](iterable []T, n int) []T {
+	m := len(iterable)
+	if n < 0 || m == 0 {
+		return nil
+	}
+
+	results := make([]T, n)
+
+	for i := range results {
+		results[i] = iterable[i%m]
+	}
+
+	return results
+}
+
+// Compress returns a slice of the elements of data for which the
+// corresponding element of selectors is true.
+//
+//  Compress([]int{1, 2, 3, 4}, []bool{true, false, true, false}) -> [1 3]
+func Compress[T any](data []T, selectors []bool) []T {
+	n := len(data)
+	if len(selectors) < n {
+		n = len(selectors)
+	}
+
+	results := []T{}
+
+	for i := 0; i < n; i++ {
+		if selectors[i] {
+			results = append(results, data[i])
+		}
+	}
+
+	return results
+}
+
+// TakeWhile returns the leading elements of iterable for which
+// predicate is true, stopping at the first element where it is false.
+//
+// A nil predicate returns an empty slice.
+func TakeWhile[T any](predicate func(T) bool, iterable []T) []T {
+	results := []T{}
+
+	if predicate != nil {
+		for _, v := range iterable {
+			if predicate(v) {
+				results = append(results, v)
+			} else {
+				break
+			}
+		}
+	}
+
+	return results
+}
+
+// IZip aggregates elements from each of the iterables, truncating to
+// the shortest input, matching itertools.zip.
+//
+//  IZip([]int{1, 2, 3}, []int{4, 5, 6}) -> [[1 4] [2 5] [3 6]]
+func IZip[T any](iterables ...[]T) [][]T {
+	if len(iterables) == 0 {
+		return nil
+	}
+
+	n := len(iterables[0])
+	for _, v := range iterables[1:] {
+		if len(v) < n {
+			n = len(v)
+		}
+	}
+
+	results := make([][]T, n)
+	for i := range results {
+		tuple := make([]T, len(iterables))
+		for j, v := range iterables {
+			tuple[j] = v[i]
+		}
+		results[i] = tuple
+	}
+
+	return results
+}
+
+// Product computes the Cartesian product of the input pools, matching
+// itertools.product.
+//
+// Any empty pool returns nil.
+//
+//  Product([]int{1, 2}, []int{3, 4}) -> [[1 3] [1 4] [2 3] [2 4]]
+func Product[T any](pools ...[]T) [][]T {
+	npools := len(pools)
+	indices := make([]int, npools)
+
+	result := make([]T, npools)
+	for i := range result {
+		if len(pools[i]) == 0 {
+			return nil
+		}
+		result[i] = pools[i][0]
+	}
+
+	results := [][]T{result}
+
+	for {
+		i := npools - 1
+		for ; i >= 0; i-- {
+			indices[i]++
+			if indices[i] < len(pools[i]) {
+				break
+			}
+			indices[i] = 0
+		}
+
+		if i < 0 {
+			return results
+		}
+
+		tuple := make([]T, npools)
+		for j, pool := range pools {
+			tuple[j] = pool[indices[j]]
+		}
+
+		results = append(results, tuple)
+	}
+}
+
+// Permutations returns successive r length permutations of elements in
+// iterable.
+//
+// Elements are treated as unique based on their position, not on their
+// value.
+func Permutations[T any](iterable []T, r int) [][]T {
+	pool := iterable
+	n := len(pool)
+
+	if r > n || r == 0 {
+		return nil
+	}
+
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+
+	cycles := make([]int, r)
+	for i := range cycles {
+		cycles[i] = n - i
+	}
+
+	result := make([]T, r)
+	for i, el := range indices[:r] {
+		result[i] = pool[el]
+	}
+
+	results := [][]T{result}
+
+	for n > 0 {
+		i := r - 1
+		for ; i >= 0; i-- {
+			cycles[i]--
+			if cycles[i] == 0 {
+				index := indices[i]
+				for j := i; j < n-1; j++ {
+					indices[j] = indices[j+1]
+				}
+				indices[n-1] = index
+				cycles[i] = n - i
+			} else {
+				j := cycles[i]
+				indices[i], indices[n-j] = indices[n-j], indices[i]
+
+				result := make([]T, r)
+				for k := 0; k < r; k++ {
+					result[k] = pool[indices[k]]
+				}
+
+				results = append(results, result)
+
+				break
+			}
+		}
+
+		if i < 0 {
+			return results
+		}
+	}
+
+	return nil
+}
+
+// Combinations returns r length subsequences of elements from
+// iterable.
+//
+// Elements are treated as unique based on their position, not on their
+// value. So if the input elements are unique, there will be no repeat
+// values in each combination.
+//
+//  Combinations([]int{1, 2, 3, 4, 5}, 4) -> [[1 2 3 4] [1 2 3 5] [1 2 4 5] [1 3 4 5] [2 3 4 5]]
+func Combinations[T any](iterable []T, r int) [][]T {
+	pool := iterable
+	n := len(pool)
+
+	if r > n || r == 0 {
+		return nil
+	}
+
+	indices := make([]int, r)
+	for i := range indices {
+		indices[i] = i
+	}
+
+	result := make([]T, r)
+	for i, el := range indices {
+		result[i] = pool[el]
+	}
+
+	results := [][]T{result}
+
+	for {
+		i := r - 1
+		for ; i >= 0 && indices[i] == i+n-r; i-- {
+		}
+
+		if i < 0 {
+			return results
+		}
+
+		indices[i]++
+		for j := i + 1; j < r; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+
+		result := make([]T, r)
+		for k, el := range indices {
+			result[k] = pool[el]
+		}
+
+		results = append(results, result)
+	}
+}
+
+// Zip2 aggregates elements from a and b into Pairs, truncating to the
+// shorter input, the heterogeneous counterpart to IZip.
+//
+//  Zip2([]int{1, 2}, []string{"a", "b"}) -> [{1 a} {2 b}]
+func Zip2[A, B any](a []A, b []B) []tuples.Pair[A, B] {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	results := make([]tuples.Pair[A, B], n)
+	for i := 0; i < n; i++ {
+		results[i] = tuples.NewPair(a[i], b[i])
+	}
+
+	return results
+}
+
+// Quad is a heterogeneous 4-tuple, used by Zip4. It stays local to v2
+// rather than moving to the tuples package, since nothing else in the
+// API needs a 4-tuple yet.
+type Quad[A, B, C, D any] struct {
+	First  A
+	Second B
+	Third  C
+	Fourth D
+}
+
+// Zip3 aggregates elements from a, b and c into Triples, truncating to
+// the shortest input, extending Zip2 to three parallel columns.
+//
+//  Zip3([]int{1, 2}, []string{"a", "b"}, []bool{true, false}) -> [{1 a true} {2 b false}]
+func Zip3[A, B, C any](a []A, b []B, c []C) []tuples.Triple[A, B, C] {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	if len(c) < n {
+		n = len(c)
+	}
+
+	results := make([]tuples.Triple[A, B, C], n)
+	for i := 0; i < n; i++ {
+		results[i] = tuples.NewTriple(a[i], b[i], c[i])
+	}
+
+	return results
+}
+
+// Zip4 aggregates elements from a, b, c and d into Quads, truncating to
+// the shortest input, extending Zip3 to four parallel columns.
+func Zip4[A, B, C, D any](a []A, b []B, c []C, d []D) []Quad[A, B, C, D] {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	if len(c) < n {
+		n = len(c)
+	}
+	if len(d) < n {
+		n = len(d)
+	}
+
+	results := make([]Quad[A, B, C, D], n)
+	for i := 0; i < n; i++ {
+		results[i] = Quad[A, B, C, D]{First: a[i], Second: b[i], Third: c[i], Fourth: d[i]}
+	}
+
+	return results
+}
+
+// ProductAny computes the Cartesian product of pools holding
+// arbitrary, possibly differently typed, elements, for building test
+// matrices out of columns like hosts ([]string), ports ([]int) and TLS
+// flags ([]bool) without forcing every pool into the same type
+// parameter the way Product requires.
+//
+// Any empty pool returns nil.
+//
+//  ProductAny([]any{"a", "b"}, []any{1, 2}) -> [[a 1] [a 2] [b 1] [b 2]]
+func ProductAny(pools ...[]any) [][]any {
+	npools := len(pools)
+	indices := make([]int, npools)
+
+	result := make([]any, npools)
+	for i := range result {
+		if len(pools[i]) == 0 {
+			return nil
+		}
+		result[i] = pools[i][0]
+	}
+
+	results := [][]any{result}
+
+	for {
+		i := npools - 1
+		for ; i >= 0; i-- {
+			indices[i]++
+			if indices[i] < len(pools[i]) {
+				break
+			}
+			indices[i] = 0
+		}
+
+		if i < 0 {
+			return results
+		}
+
+		tuple := make([]any, npools)
+		for j, pool := range pools {
+			tuple[j] = pool[indices[j]]
+		}
+
+		results = append(results, tuple)
+	}
+}
+
+// Number is the set of types Count accepts: any signed or unsigned
+// integer type, or any floating-point type. It is defined locally
+// rather than pulled from golang.org/x/exp/constraints so that this
+// package has no dependency outside the standard library.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Count returns a slice with step-spaced values from the range
+// beginning with start and ending before stop, generalizing the
+// int-only itertools.Count to any integer or floating-point type so
+// time-offset and monetary use cases aren't truncated.
+//
+//  Count(1, 10, 1) -> [1 2 3 4 5 6 7 8 9]
+func Count[T Number](start, stop, step T) []T {
+	if step*(stop-start) <= 0 {
+		return nil
+	}
+
+	results := []T{}
+
+	for i := start; (step > 0 && i < stop) ||
+		(step < 0 && i > stop); i += step {
+		results = append(results, i)
+	}
+
+	return results
+}
+
+// Unzip splits pairs back into two parallel slices, the inverse of
+// Zip2, so zipped results can be separated back into columns for
+// plotting or bulk insertion.
+//  Unzip([]tuples.Pair[int, string]{{1, "a"}, {2, "b"}}) -> [1 2], [a b]
+func Unzip[A, B any](pairs []tuples.Pair[A, B]) ([]A, []B) {
+	as := make([]A, len(pairs))
+	bs := make([]B, len(pairs))
+
+	for i, p := range pairs {
+		as[i] = p.First
+		bs[i] = p.Second
+	}
+
+	return as, bs
+}
+
+// UnzipInts splits a slice of int pairs back into two parallel []int
+// slices, the int-only counterpart to Unzip for code already working
+// with IZip's [][]int tuples rather than Pair.
+//  UnzipInts([][]int{{1, 4}, {2, 5}, {3, 6}}) -> [1 2 3], [4 5 6]
+func UnzipInts(pairs [][]int) ([]int, []int) {
+	as := make([]int, len(pairs))
+	bs := make([]int, len(pairs))
+
+	for i, p := range pairs {
+		as[i] = p[0]
+		bs[i] = p[1]
+	}
+
+	return as, bs
+}
+
+// GroupIntoMap buckets every element of items by key(item), regardless
+// of input ordering, unlike the consecutive-run semantics of
+// itertools.groupby. This is the more commonly needed grouping when the
+// input isn't already sorted by key.
+//  GroupIntoMap([]int{1, 2, 3, 4}, isEven) -> map[false:[1 3] true:[2 4]]
+func GroupIntoMap[T any, K comparable](items []T, key func(T) K) map[K][]T {
+	groups := map[K][]T{}
+
+	for _, item := range items {
+		k := key(item)
+		groups[k] = append(groups[k], item)
+	}
+
+	return groups
+}
+
+// Map applies fn to each element of in, returning a new slice of
+// possibly different element type, the generic building block that
+// IFilter/IFilterFalse can't provide since they only transform int to
+// int.
+//  Map([]int{1, 2, 3}, strconv.Itoa) -> [1 2 3]
+func Map[T, U any](in []T, fn func(T) U) []U {
+	results := make([]U, len(in))
+
+	for i, v := range in {
+		results[i] = fn(v)
+	}
+
+	return results
+}
+
+// Filter returns the elements of in for which pred is true, the generic
+// counterpart to IFilter that works over any element type rather than
+// just int.
+func Filter[T any](in []T, pred func(T) bool) []T {
+	results := []T{}
+
+	for _, v := range in {
+		if pred(v) {
+			results = append(results, v)
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,308 @@
+// Copyright 2012 Nuno Antunes. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/ntns/goitertools/tuples"
+)
+
+func TestChain(t *testing.T) {
+	// general case
+	if v := Chain([]int{1, 2, 3}, []int{4, 5, 6}); !sliceMatch(v, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("Chain([1 2 3], [4 5 6]) should return [1 2 3 4 5 6], got %v", v)
+	}
+
+	// strings
+	if v := Chain([]string{"a", "b"}, []string{"c"}); !sliceMatch(v, []string{"a", "b", "c"}) {
+		t.Errorf(`Chain([a b], [c]) should return [a b c], got %v`, v)
+	}
+}
+
+func TestCycle(t *testing.T) {
+	// general case
+	if v := Cycle([]int{1, 2, 3, 4}, 6); !sliceMatch(v, []int{1, 2, 3, 4, 1, 2}) {
+		t.Errorf("Cycle([1 2 3 4], 6) should return [1 2 3 4 1 2], got %v", v)
+	}
+
+	// n < 0
+	if v := Cycle([]int{1}, -1); v != nil {
+		t.Errorf("Cycle([1], -1) should return nil, got %v", v)
+	}
+
+	// empty iterable
+	if v := Cycle([]int{}, 3); v != nil {
+		t.Errorf("Cycle([], 3) should return nil, got %v", v)
+	}
+}
+
+func TestCompress(t *testing.T) {
+	// general case
+	if v := Compress([]int{1, 2, 3, 4}, []bool{true, false, true, false}); !sliceMatch(v, []int{1, 3}) {
+		t.Errorf("Compress([1 2 3 4], [true false true false]) should return [1 3], got %v", v)
+	}
+}
+
+func TestTakeWhile(t *testing.T) {
+	isPositive := func(x int) bool { return x > 0 }
+
+	// general case
+	if v := TakeWhile(isPositive, []int{1, 2, -1, 3}); !sliceMatch(v, []int{1, 2}) {
+		t.Errorf("TakeWhile(isPositive, [1 2 -1 3]) should return [1 2], got %v", v)
+	}
+
+	// nil predicate
+	if v := TakeWhile[int](nil, []int{1, 2, 3}); !sliceMatch(v, []int{}) {
+		t.Errorf("TakeWhile(nil, [1 2 3]) should return [], got %v", v)
+	}
+}
+
+func TestIZip(t *testing.T) {
+	// general case
+	if v := IZip([]int{1, 2, 3}, []int{4, 5, 6}); !slice2dMatch(v, [][]int{{1, 4}, {2, 5}, {3, 6}}) {
+		t.Errorf("IZip([1 2 3], [4 5 6]) should return [[1 4] [2 5] [3 6]], got %v", v)
+	}
+
+	// no iterables
+	if v := IZip[int](); v != nil {
+		t.Errorf("IZip() should return nil, got %v", v)
+	}
+}
+
+func TestProduct(t *testing.T) {
+	// general case
+	if v := Product([]int{1, 2}, []int{3, 4}); !slice2dMatch(v, [][]int{{1, 3}, {1, 4}, {2, 3}, {2, 4}}) {
+		t.Errorf("Product([1 2], [3 4]) should return [[1 3] [1 4] [2 3] [2 4]], got %v", v)
+	}
+
+	// empty pool
+	if v := Product([]int{1, 2}, []int{}); v != nil {
+		t.Errorf("Product([1 2], []) should return nil, got %v", v)
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	// general case
+	if v := Permutations([]int{1, 2, 3}, 2); !slice2dMatch(v, [][]int{{1, 2}, {1, 3}, {2, 1}, {2, 3}, {3, 1}, {3, 2}}) {
+		t.Errorf("Permutations([1 2 3], 2) should return [[1 2] [1 3] [2 1] [2 3] [3 1] [3 2]], got %v", v)
+	}
+
+	// r > n
+	if v := Permutations([]int{1, 2}, 3); v != nil {
+		t.Errorf("Permutations([1 2], 3) should return nil, got %v", v)
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	// general case
+	if v := Combinations([]int{1, 2, 3, 4, 5}, 4); !slice2dMatch(v, [][]int{{1, 2, 3, 4}, {1, 2, 3, 5}, {1, 2, 4, 5}, {1, 3, 4, 5}, {2, 3, 4, 5}}) {
+		t.Errorf("Combinations([1 2 3 4 5], 4) should return [[1 2 3 4] [1 2 3 5] [1 2 4 5] [1 3 4 5] [2 3 4 5]], got %v", v)
+	}
+
+	// r > n
+	if v := Combinations([]int{1, 2}, 3); v != nil {
+		t.Errorf("Combinations([1 2], 3) should return nil, got %v", v)
+	}
+}
+
+func TestZip2(t *testing.T) {
+	// general case
+	v := Zip2([]int{1, 2}, []string{"a", "b"})
+	want := []tuples.Pair[int, string]{tuples.NewPair(1, "a"), tuples.NewPair(2, "b")}
+	if len(v) != len(want) {
+		t.Fatalf("Zip2([1 2], [a b]) should return %v, got %v", want, v)
+	}
+	for i := range v {
+		if v[i] != want[i] {
+			t.Errorf("Zip2([1 2], [a b])[%d] should be %v, got %v", i, want[i], v[i])
+		}
+	}
+
+	// unequal lengths truncate to shorter
+	if v := Zip2([]int{1, 2, 3}, []string{"a"}); len(v) != 1 || v[0] != (tuples.Pair[int, string]{First: 1, Second: "a"}) {
+		t.Errorf("Zip2([1 2 3], [a]) should return [{1 a}], got %v", v)
+	}
+}
+
+func TestZip3(t *testing.T) {
+	// general case
+	v := Zip3([]int{1, 2}, []string{"a", "b"}, []bool{true, false})
+	want := []tuples.Triple[int, string, bool]{tuples.NewTriple(1, "a", true), tuples.NewTriple(2, "b", false)}
+	if len(v) != len(want) {
+		t.Fatalf("Zip3(...) should return %v, got %v", want, v)
+	}
+	for i := range v {
+		if v[i] != want[i] {
+			t.Errorf("Zip3(...)[%d] should be %v, got %v", i, want[i], v[i])
+		}
+	}
+}
+
+func TestZip4(t *testing.T) {
+	// general case
+	v := Zip4([]int{1, 2}, []string{"a", "b"}, []bool{true, false}, []float64{1.5, 2.5})
+	want := []Quad[int, string, bool, float64]{{1, "a", true, 1.5}, {2, "b", false, 2.5}}
+	if len(v) != len(want) {
+		t.Fatalf("Zip4(...) should return %v, got %v", want, v)
+	}
+	for i := range v {
+		if v[i] != want[i] {
+			t.Errorf("Zip4(...)[%d] should be %v, got %v", i, want[i], v[i])
+		}
+	}
+}
+
+func TestProductAny(t *testing.T) {
+	// general case
+	v := ProductAny([]any{"a", "b"}, []any{1, 2})
+	want := [][]any{{"a", 1}, {"a", 2}, {"b", 1}, {"b", 2}}
+	if len(v) != len(want) {
+		t.Fatalf("ProductAny([a b], [1 2]) should return %v, got %v", want, v)
+	}
+	for i := range v {
+		if len(v[i]) != len(want[i]) || v[i][0] != want[i][0] || v[i][1] != want[i][1] {
+			t.Errorf("ProductAny([a b], [1 2])[%d] should be %v, got %v", i, want[i], v[i])
+		}
+	}
+
+	// empty pool
+	if v := ProductAny([]any{"a"}, []any{}); v != nil {
+		t.Errorf("ProductAny([a], []) should return nil, got %v", v)
+	}
+}
+
+func TestPermutationsStrings(t *testing.T) {
+	// scheduling/ordering over non-int element types
+	if v := Permutations([]string{"a", "b"}, 2); !slice2dMatch(v, [][]string{{"a", "b"}, {"b", "a"}}) {
+		t.Errorf(`Permutations([a b], 2) should return [[a b] [b a]], got %v`, v)
+	}
+}
+
+func TestCombinationsStructs(t *testing.T) {
+	type task struct {
+		Name     string
+		Priority int
+	}
+
+	a := task{Name: "a", Priority: 1}
+	b := task{Name: "b", Priority: 2}
+	c := task{Name: "c", Priority: 3}
+
+	v := Combinations([]task{a, b, c}, 2)
+	want := [][]task{{a, b}, {a, c}, {b, c}}
+	if len(v) != len(want) {
+		t.Fatalf("Combinations([a b c], 2) should return %v, got %v", want, v)
+	}
+	for i := range v {
+		if len(v[i]) != len(want[i]) || v[i][0] != want[i][0] || v[i][1] != want[i][1] {
+			t.Errorf("Combinations([a b c], 2)[%d] should be %v, got %v", i, want[i], v[i])
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	// int
+	if v := Count(1, 10, 1); !sliceMatch(v, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("Count(1, 10, 1) should return [1 2 3 4 5 6 7 8 9], got %v", v)
+	}
+
+	// float64
+	if v := Count(0.0, 1.0, 0.25); !sliceMatch(v, []float64{0, 0.25, 0.5, 0.75}) {
+		t.Errorf("Count(0.0, 1.0, 0.25) should return [0 0.25 0.5 0.75], got %v", v)
+	}
+
+	// step*(stop-start) <= 0
+	if v := Count(1, 10, 0); v != nil {
+		t.Errorf("Count(1, 10, 0) should return nil, got %v", v)
+	}
+	if v := Count(1, 10, -1); v != nil {
+		t.Errorf("Count(1, 10, -1) should return nil, got %v", v)
+	}
+}
+
+func TestUnzip(t *testing.T) {
+	pairs := []tuples.Pair[int, string]{tuples.NewPair(1, "a"), tuples.NewPair(2, "b")}
+
+	// general case
+	as, bs := Unzip(pairs)
+	if !sliceMatch(as, []int{1, 2}) || !sliceMatch(bs, []string{"a", "b"}) {
+		t.Errorf("Unzip(%v) should return [1 2], [a b], got %v, %v", pairs, as, bs)
+	}
+}
+
+func TestUnzipInts(t *testing.T) {
+	// general case
+	as, bs := UnzipInts([][]int{{1, 4}, {2, 5}, {3, 6}})
+	if !sliceMatch(as, []int{1, 2, 3}) || !sliceMatch(bs, []int{4, 5, 6}) {
+		t.Errorf("UnzipInts(...) should return [1 2 3], [4 5 6], got %v, %v", as, bs)
+	}
+}
+
+func TestGroupIntoMap(t *testing.T) {
+	isEven := func(x int) bool { return x%2 == 0 }
+
+	// general case, out of order input still buckets correctly
+	v := GroupIntoMap([]int{1, 2, 3, 4}, isEven)
+	if !sliceMatch(v[false], []int{1, 3}) || !sliceMatch(v[true], []int{2, 4}) {
+		t.Errorf("GroupIntoMap([1 2 3 4], isEven) should return map[false:[1 3] true:[2 4]], got %v", v)
+	}
+
+	// empty input
+	if v := GroupIntoMap([]int{}, isEven); len(v) != 0 {
+		t.Errorf("GroupIntoMap([], isEven) should return an empty map, got %v", v)
+	}
+}
+
+func TestMap(t *testing.T) {
+	double := func(x int) int { return x * 2 }
+
+	// general case; the toString case below changes element type
+	if v := Map([]int{1, 2, 3}, double); !sliceMatch(v, []int{2, 4, 6}) {
+		t.Errorf("Map([1 2 3], double) should return [2 4 6], got %v", v)
+	}
+
+	toString := func(x int) string { return string(rune('0' + x)) }
+	if v := Map([]int{1, 2, 3}, toString); !sliceMatch(v, []string{"1", "2", "3"}) {
+		t.Errorf("Map([1 2 3], toString) should return [1 2 3], got %v", v)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	isPositive := func(x int) bool { return x > 0 }
+
+	// general case
+	if v := Filter([]int{1, -2, 3, -4}, isPositive); !sliceMatch(v, []int{1, 3}) {
+		t.Errorf("Filter([1 -2 3 -4], isPositive) should return [1 3], got %v", v)
+	}
+}
+
+func sliceMatch[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func slice2dMatch[T comparable](a, b [][]T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, s := range a {
+		if !sliceMatch(s, b[i]) {
+			return false
+		}
+	}
+
+	return true
+}